@@ -1,7 +1,5 @@
 package v1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-
 // VolumeGroupDeletionPolicy describes a policy for end-of-life maintenance of
 // volume group contents
 type VolumeGroupDeletionPolicy string
@@ -16,13 +14,44 @@ const (
 	VolumeGroupContentRetain VolumeGroupDeletionPolicy = "Retain"
 )
 
-// Describes an error encountered on the group
-type VolumeGroupError struct {
-	// time is the timestamp when the error was encountered.
-	// +optional
-	Time *metav1.Time `json:"time,omitempty"`
+// PersistentVolumeClaimDeletionPolicy describes what happens to the persistentVolumeClaims
+// of a volume group when the volume group is deleted
+type PersistentVolumeClaimDeletionPolicy string
+
+const (
+	// PersistentVolumeClaimDelete means the persistentVolumeClaims of the group
+	// are deleted with the group.
+	PersistentVolumeClaimDelete PersistentVolumeClaimDeletionPolicy = "Delete"
+
+	// PersistentVolumeClaimRetain means the persistentVolumeClaims of the group
+	// are left when the group is deleted.
+	PersistentVolumeClaimRetain PersistentVolumeClaimDeletionPolicy = "Retain"
+)
+
+// Condition types reported in the status of VolumeGroup and VolumeGroupContent objects
+const (
+	// ConditionReady is True when the group is bound, its membership is in sync
+	// with the storage system and nothing blocks it.
+	ConditionReady = "Ready"
+
+	// ConditionBound is True when the group is bound to its counterpart
+	// VolumeGroup or VolumeGroupContent.
+	ConditionBound = "Bound"
+
+	// ConditionMembershipSynced is True when the last membership change of the
+	// group was applied on the storage system.
+	ConditionMembershipSynced = "MembershipSynced"
 
-	// message details the encountered error
-	// +optional
-	Message *string `json:"message,omitempty"`
-}
+	// ConditionDriverReachable is False when the last call to the CSI driver
+	// failed because the driver could not be reached.
+	ConditionDriverReachable = "DriverReachable"
+
+	// ConditionDeletionBlocked is True when the deletion of the group cannot
+	// complete.
+	ConditionDeletionBlocked = "DeletionBlocked"
+
+	// ConditionReconciled is False when the last reconcile of the group failed
+	// for a reason that is not reported by the other conditions. It does not
+	// affect Ready.
+	ConditionReconciled = "Reconciled"
+)
@@ -0,0 +1,238 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+
+	v2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// The members of v1 are full persistentVolumeClaim and persistentVolume objects, they are converted
+// to and from the member references of v2. Only the fields that the references keep are set on the
+// objects of v1. The volume handle of volumeGroup members and the time members were added have no
+// field in v1, they are kept in the membersAnnotation of v1 objects so that a v2 object read and
+// written back through v1 loses nothing.
+
+const membersAnnotation = "csi.ibm.com/v2-members"
+
+// ConvertTo converts the volumeGroup to the hub version.
+func (src *VolumeGroup) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v2.VolumeGroup)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v2.VolumeGroupSpec{
+		VolumeGroupClassName: src.Spec.VolumeGroupClassName,
+		Source: v2.VolumeGroupSource{
+			VolumeGroupContentName:     src.Spec.Source.VolumeGroupContentName,
+			Selector:                   src.Spec.Source.Selector,
+			PersistentVolumeClaimNames: src.Spec.Source.PersistentVolumeClaimNames,
+		},
+	}
+	dst.Status = v2.VolumeGroupStatus{
+		BoundVolumeGroupContentName: src.Status.BoundVolumeGroupContentName,
+		GroupCreationTime:           src.Status.GroupCreationTime,
+		ObservedGeneration:          src.Status.ObservedGeneration,
+		Conditions:                  src.Status.Conditions,
+	}
+	for _, pvc := range src.Status.PVCList {
+		dst.Status.Members = append(dst.Status.Members, v2.VolumeGroupMember{
+			Name:                 pvc.Name,
+			Namespace:            pvc.Namespace,
+			UID:                  pvc.UID,
+			PersistentVolumeName: pvc.Spec.VolumeName,
+		})
+	}
+	return restoreMembers(&dst.ObjectMeta, dst.Status.Members)
+}
+
+// ConvertFrom converts the volumeGroup from the hub version.
+func (dst *VolumeGroup) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v2.VolumeGroup)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = VolumeGroupSpec{
+		VolumeGroupClassName: src.Spec.VolumeGroupClassName,
+		Source: VolumeGroupSource{
+			VolumeGroupContentName:     src.Spec.Source.VolumeGroupContentName,
+			Selector:                   src.Spec.Source.Selector,
+			PersistentVolumeClaimNames: src.Spec.Source.PersistentVolumeClaimNames,
+		},
+	}
+	dst.Status = VolumeGroupStatus{
+		BoundVolumeGroupContentName: src.Status.BoundVolumeGroupContentName,
+		GroupCreationTime:           src.Status.GroupCreationTime,
+		ObservedGeneration:          src.Status.ObservedGeneration,
+		Conditions:                  src.Status.Conditions,
+	}
+	for _, member := range src.Status.Members {
+		dst.Status.PVCList = append(dst.Status.PVCList, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: member.Name, Namespace: member.Namespace, UID: member.UID},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: member.PersistentVolumeName},
+		})
+	}
+	return saveMembers(&dst.ObjectMeta, src.Status.Members)
+}
+
+// ConvertTo converts the volumeGroupContent to the hub version.
+func (src *VolumeGroupContent) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v2.VolumeGroupContent)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v2.VolumeGroupContentSpec{
+		VolumeGroupClassName:       src.Spec.VolumeGroupClassName,
+		VolumeGroupRef:             src.Spec.VolumeGroupRef,
+		SupportVolumeGroupSnapshot: src.Spec.SupportVolumeGroupSnapshot,
+		VolumeGroupSecretRef:       src.Spec.VolumeGroupSecretRef,
+	}
+	if src.Spec.Source != nil {
+		dst.Spec.Source = &v2.VolumeGroupContentSource{
+			Driver:                src.Spec.Source.Driver,
+			VolumeGroupHandle:     src.Spec.Source.VolumeGroupHandle,
+			VolumeGroupAttributes: src.Spec.Source.VolumeGroupAttributes,
+		}
+	}
+	if src.Spec.VolumeGroupDeletionPolicy != nil {
+		deletionPolicy := v2.VolumeGroupDeletionPolicy(*src.Spec.VolumeGroupDeletionPolicy)
+		dst.Spec.VolumeGroupDeletionPolicy = &deletionPolicy
+	}
+	dst.Status = v2.VolumeGroupContentStatus{
+		GroupCreationTime:  src.Status.GroupCreationTime,
+		Phase:              v2.VolumeGroupContentPhase(src.Status.Phase),
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         src.Status.Conditions,
+	}
+	for _, pv := range src.Status.PVList {
+		member := v2.VolumeGroupMember{PersistentVolumeName: pv.Name}
+		if pv.Spec.ClaimRef != nil {
+			member.Name = pv.Spec.ClaimRef.Name
+			member.Namespace = pv.Spec.ClaimRef.Namespace
+			member.UID = pv.Spec.ClaimRef.UID
+		}
+		if pv.Spec.CSI != nil {
+			member.VolumeHandle = pv.Spec.CSI.VolumeHandle
+		}
+		dst.Status.Members = append(dst.Status.Members, member)
+	}
+	return restoreMembers(&dst.ObjectMeta, dst.Status.Members)
+}
+
+// ConvertFrom converts the volumeGroupContent from the hub version.
+func (dst *VolumeGroupContent) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v2.VolumeGroupContent)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = VolumeGroupContentSpec{
+		VolumeGroupClassName:       src.Spec.VolumeGroupClassName,
+		VolumeGroupRef:             src.Spec.VolumeGroupRef,
+		SupportVolumeGroupSnapshot: src.Spec.SupportVolumeGroupSnapshot,
+		VolumeGroupSecretRef:       src.Spec.VolumeGroupSecretRef,
+	}
+	driver := ""
+	if src.Spec.Source != nil {
+		driver = src.Spec.Source.Driver
+		dst.Spec.Source = &VolumeGroupContentSource{
+			Driver:                src.Spec.Source.Driver,
+			VolumeGroupHandle:     src.Spec.Source.VolumeGroupHandle,
+			VolumeGroupAttributes: src.Spec.Source.VolumeGroupAttributes,
+		}
+	}
+	if src.Spec.VolumeGroupDeletionPolicy != nil {
+		deletionPolicy := VolumeGroupDeletionPolicy(*src.Spec.VolumeGroupDeletionPolicy)
+		dst.Spec.VolumeGroupDeletionPolicy = &deletionPolicy
+	}
+	dst.Status = VolumeGroupContentStatus{
+		GroupCreationTime:  src.Status.GroupCreationTime,
+		Phase:              VolumeGroupContentPhase(src.Status.Phase),
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         src.Status.Conditions,
+	}
+	for _, member := range src.Status.Members {
+		pv := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: member.PersistentVolumeName}}
+		if member.Name != "" {
+			pv.Spec.ClaimRef = &corev1.ObjectReference{
+				Kind:      "PersistentVolumeClaim",
+				Name:      member.Name,
+				Namespace: member.Namespace,
+				UID:       member.UID,
+			}
+		}
+		if member.VolumeHandle != "" {
+			pv.Spec.CSI = &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: member.VolumeHandle}
+		}
+		dst.Status.PVList = append(dst.Status.PVList, pv)
+	}
+	return saveMembers(&dst.ObjectMeta, src.Status.Members)
+}
+
+// saveMembers keeps the members of the hub version in the annotations of the v1 object.
+func saveMembers(objectMeta *metav1.ObjectMeta, members []v2.VolumeGroupMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+	value, err := json.Marshal(members)
+	if err != nil {
+		return err
+	}
+	annotations := make(map[string]string, len(objectMeta.Annotations)+1)
+	for key, annotation := range objectMeta.Annotations {
+		annotations[key] = annotation
+	}
+	annotations[membersAnnotation] = string(value)
+	objectMeta.Annotations = annotations
+	return nil
+}
+
+// restoreMembers removes the members kept by saveMembers from the annotations of the hub object
+// and fills in the fields v1 has no room for, on the members that are still in the group.
+func restoreMembers(objectMeta *metav1.ObjectMeta, members []v2.VolumeGroupMember) error {
+	value, ok := objectMeta.Annotations[membersAnnotation]
+	if !ok {
+		return nil
+	}
+	annotations := make(map[string]string, len(objectMeta.Annotations)-1)
+	for key, annotation := range objectMeta.Annotations {
+		if key != membersAnnotation {
+			annotations[key] = annotation
+		}
+	}
+	objectMeta.Annotations = annotations
+	if len(annotations) == 0 {
+		objectMeta.Annotations = nil
+	}
+
+	savedMembers := []v2.VolumeGroupMember{}
+	if err := json.Unmarshal([]byte(value), &savedMembers); err != nil {
+		return err
+	}
+	for index := range members {
+		for _, savedMember := range savedMembers {
+			if isSameMember(members[index], savedMember) {
+				if members[index].VolumeHandle == "" {
+					members[index].VolumeHandle = savedMember.VolumeHandle
+				}
+				members[index].AddedTime = savedMember.AddedTime
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func isSameMember(member, savedMember v2.VolumeGroupMember) bool {
+	return member.Name == savedMember.Name && member.Namespace == savedMember.Namespace &&
+		member.UID == savedMember.UID && member.PersistentVolumeName == savedMember.PersistentVolumeName
+}
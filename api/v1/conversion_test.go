@@ -0,0 +1,112 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestMembers() []v2.VolumeGroupMember {
+	addedTime := metav1.NewTime(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	return []v2.VolumeGroupMember{
+		{Name: "pvc-0", Namespace: "default", UID: "uid-0", PersistentVolumeName: "pv-0",
+			VolumeHandle: "volume-0", AddedTime: &addedTime},
+		{Name: "pvc-1", Namespace: "default", UID: "uid-1", PersistentVolumeName: "pv-1",
+			VolumeHandle: "volume-1"},
+	}
+}
+
+// TestVolumeGroupRoundTrip converts a v2 volumeGroup to v1 and back and checks that nothing is lost.
+func TestVolumeGroupRoundTrip(t *testing.T) {
+	contentName := "volume-group-content"
+	hub := &v2.VolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-group", Namespace: "default",
+			Annotations: map[string]string{"owner": "test"}},
+		Status: v2.VolumeGroupStatus{BoundVolumeGroupContentName: &contentName, Members: newTestMembers()},
+	}
+	spoke := &VolumeGroup{}
+	if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	if len(spoke.Status.PVCList) != len(hub.Status.Members) {
+		t.Fatalf("expected %d persistentVolumeClaims in v1, got %d", len(hub.Status.Members), len(spoke.Status.PVCList))
+	}
+
+	converted := &v2.VolumeGroup{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	if !apiequality.Semantic.DeepEqual(hub, converted) {
+		t.Errorf("round trip changed the volumeGroup:\nexpected %+v\ngot      %+v", hub, converted)
+	}
+}
+
+// TestVolumeGroupContentRoundTrip converts a v2 volumeGroupContent to v1 and back and checks that
+// nothing is lost.
+func TestVolumeGroupContentRoundTrip(t *testing.T) {
+	deletionPolicy := v2.VolumeGroupContentRetain
+	hub := &v2.VolumeGroupContent{
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-group-content"},
+		Spec: v2.VolumeGroupContentSpec{
+			VolumeGroupDeletionPolicy: &deletionPolicy,
+			Source:                    &v2.VolumeGroupContentSource{Driver: "driver.example.com", VolumeGroupHandle: "group-0"},
+		},
+		Status: v2.VolumeGroupContentStatus{Members: newTestMembers()},
+	}
+	spoke := &VolumeGroupContent{}
+	if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+
+	converted := &v2.VolumeGroupContent{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	if !apiequality.Semantic.DeepEqual(hub, converted) {
+		t.Errorf("round trip changed the volumeGroupContent:\nexpected %+v\ngot      %+v", hub, converted)
+	}
+}
+
+// TestVolumeGroupConversionKeepsRemovedMembersOut checks that a member removed by a v1 client is
+// not brought back by the members kept in the annotations.
+func TestVolumeGroupConversionKeepsRemovedMembersOut(t *testing.T) {
+	hub := &v2.VolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "volume-group", Namespace: "default"},
+		Status:     v2.VolumeGroupStatus{Members: newTestMembers()},
+	}
+	spoke := &VolumeGroup{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	spoke.Status.PVCList = spoke.Status.PVCList[:1]
+
+	converted := &v2.VolumeGroup{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	if !apiequality.Semantic.DeepEqual(hub.Status.Members[:1], converted.Status.Members) {
+		t.Errorf("expected members %+v, got %+v", hub.Status.Members[:1], converted.Status.Members)
+	}
+	if _, ok := converted.Annotations[membersAnnotation]; ok {
+		t.Errorf("expected the %s annotation to be removed from the hub version", membersAnnotation)
+	}
+}
@@ -32,7 +32,8 @@ type VolumeGroupSpec struct {
 }
 
 // VolumeGroupSource contains several options.
-// OneOf the options must be defined.
+// One of VolumeGroupContentName, Selector and PersistentVolumeClaimNames must be defined,
+// Selector cannot be used with the other options.
 type VolumeGroupSource struct {
 	// +optional
 	// Pre-provisioned VolumeGroup
@@ -42,9 +43,15 @@ type VolumeGroupSource struct {
 	// Dynamically provisioned VolumeGroup
 	// A label query over persistent volume claims to be added to the volume group.
 	// This labelSelector will be used to match the label added to a PVC.
-	// In Phase 1, when the label is added to PVC, the PVC will be added to the matching group.
-	// In Phase 2, this labelSelector will be used to find all PVCs with matching label and add them to the group when the group is being created.
+	// All PVCs with matching labels are added to the group when the group is being created,
+	// and when the label is added to a PVC later, the PVC will be added to the matching group.
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// +optional
+	// PersistentVolumeClaimNames is an explicit list of the persistent volume claims in the
+	// namespace of the volume group that are members of the group.
+	// It can be used with VolumeGroupContentName or instead of Selector.
+	PersistentVolumeClaimNames []string `json:"persistentVolumeClaimNames,omitempty"`
 }
 
 // VolumeGroupStatus defines the observed state of VolumeGroup
@@ -59,19 +66,22 @@ type VolumeGroupStatus struct {
 	// +optional
 	PVCList []corev1.PersistentVolumeClaim `json:"pvcList,omitempty"`
 
+	// ObservedGeneration is the most recent generation observed by the controller.
 	// +optional
-	Ready *bool `json:"ready,omitempty"`
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
-	// Last error encountered during group creation
+	// Conditions represent the latest available observations of the volume group's state.
 	// +optional
-	Error *VolumeGroupError `json:"error,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // VolumeGroup is a user's request for a group of volumes
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,shortName=vg
-// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="VolumeGroupClass",type=string,JSONPath=`.spec.volumeGroupClassName`
 // +kubebuilder:printcolumn:name="VolumeGroupContent",type=string,JSONPath=`.status.boundVolumeGroupContentName`
 // +kubebuilder:printcolumn:name="CreationTime",type=date,JSONPath=`.status.groupCreationTime`
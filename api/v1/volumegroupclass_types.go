@@ -45,10 +45,24 @@ type VolumeGroupClass struct {
 	// +optional
 	VolumeGroupDeletionPolicy *VolumeGroupDeletionPolicy `json:"volumeGroupDeletionPolicy,omitempty"`
 
+	// PersistentVolumeClaimDeletionPolicy sets whether the persistentVolumeClaims of a
+	// VolumeGroup are deleted with it. They are retained unless the operator is configured
+	// to delete them.
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	PersistentVolumeClaimDeletionPolicy *PersistentVolumeClaimDeletionPolicy `json:"persistentVolumeClaimDeletionPolicy,omitempty"`
+
 	// This field specifies whether group snapshot is supported.
-	// The default is false.
+	// The default is false. It is reserved, the CSI volume group API
+	// does not provide group snapshots yet.
 	// +optional
 	SupportVolumeGroupSnapshot *bool `json:"supportVolumeGroupSnapshot,omitempty"`
+
+	// RepairMembershipDrift sets whether the operator sets the membership of the
+	// group on the storage back to its VolumeGroups, when it finds they differ.
+	// The default is false, drift is only reported.
+	// +optional
+	RepairMembershipDrift *bool `json:"repairMembershipDrift,omitempty"`
 }
 
 //+kubebuilder:object:root=true
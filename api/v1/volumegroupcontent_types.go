@@ -37,7 +37,8 @@ type VolumeGroupContentSpec struct {
 	VolumeGroupDeletionPolicy *VolumeGroupDeletionPolicy `json:"volumeGroupDeletionPolicy,omitempty"`
 
 	// This field specifies whether group snapshot is supported.
-	// The default is false.
+	// The default is false. It is reserved, the CSI volume group API
+	// does not provide group snapshots yet.
 	// +optional
 	SupportVolumeGroupSnapshot *bool `json:"supportVolumeGroupSnapshot,omitempty"`
 
@@ -64,6 +65,20 @@ type VolumeGroupContentSource struct {
 	VolumeGroupAttributes map[string]string `json:"volumeGroupAttributes,omitempty"`
 }
 
+// VolumeGroupContentPhase is the binding phase of a VolumeGroupContent.
+type VolumeGroupContentPhase string
+
+const (
+	// VolumeGroupContentAvailable means the content is not bound to any VolumeGroup.
+	VolumeGroupContentAvailable VolumeGroupContentPhase = "Available"
+	// VolumeGroupContentBound means the content is bound to the VolumeGroup in VolumeGroupRef.
+	VolumeGroupContentBound VolumeGroupContentPhase = "Bound"
+	// VolumeGroupContentReleased means the bound VolumeGroup was deleted and the content was retained.
+	VolumeGroupContentReleased VolumeGroupContentPhase = "Released"
+	// VolumeGroupContentFailed means the volume group could not be deleted from the storage system.
+	VolumeGroupContentFailed VolumeGroupContentPhase = "Failed"
+)
+
 // VolumeGroupContentStatus defines the observed state of VolumeGroupContent
 type VolumeGroupContentStatus struct {
 	// +optional
@@ -73,12 +88,19 @@ type VolumeGroupContentStatus struct {
 	// +optional
 	PVList []corev1.PersistentVolume `json:"pvList,omitempty"`
 
+	// Phase indicates if the content is available, bound to a VolumeGroup or released.
+	// +optional
+	// +kubebuilder:validation:Enum=Available;Bound;Released;Failed
+	Phase VolumeGroupContentPhase `json:"phase,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller.
 	// +optional
-	Ready *bool `json:"ready,omitempty"`
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
-	// Last error encountered during group creation
+	// Conditions represent the latest available observations of the volume group content's state.
 	// +optional
-	Error *VolumeGroupError `json:"error,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 //+kubebuilder:object:root=true
@@ -86,13 +108,15 @@ type VolumeGroupContentStatus struct {
 
 // VolumeGroupContent is the Schema for the volumegroupcontents API
 // +kubebuilder:object:root=true
-// +kubebuilder:resource:scope=Namespaced,shortName=vgc
+// +kubebuilder:resource:scope=Cluster,shortName=vgc
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="DeletionPolicy",type=string,JSONPath=`.spec.volumeGroupDeletionPolicy`
 // +kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.spec.source.driver`
 // +kubebuilder:printcolumn:name="VolumeGroupClass",type=string,JSONPath=`.spec.volumeGroupClassName`
 // +kubebuilder:printcolumn:name="VolumeGroup",type=string,JSONPath=`.spec.volumeGroupRef.name`
+// +kubebuilder:printcolumn:name="VolumeGroupNamespace",type=string,JSONPath=`.spec.volumeGroupRef.namespace`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type VolumeGroupContent struct {
 	metav1.TypeMeta   `json:",inline"`
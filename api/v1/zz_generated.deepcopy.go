@@ -71,11 +71,21 @@ func (in *VolumeGroupClass) DeepCopyInto(out *VolumeGroupClass) {
 		*out = new(VolumeGroupDeletionPolicy)
 		**out = **in
 	}
+	if in.PersistentVolumeClaimDeletionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimDeletionPolicy, &out.PersistentVolumeClaimDeletionPolicy
+		*out = new(PersistentVolumeClaimDeletionPolicy)
+		**out = **in
+	}
 	if in.SupportVolumeGroupSnapshot != nil {
 		in, out := &in.SupportVolumeGroupSnapshot, &out.SupportVolumeGroupSnapshot
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RepairMembershipDrift != nil {
+		in, out := &in.RepairMembershipDrift, &out.RepairMembershipDrift
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupClass.
@@ -268,15 +278,12 @@ func (in *VolumeGroupContentStatus) DeepCopyInto(out *VolumeGroupContentStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Ready != nil {
-		in, out := &in.Ready, &out.Ready
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Error != nil {
-		in, out := &in.Error, &out.Error
-		*out = new(VolumeGroupError)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -290,30 +297,6 @@ func (in *VolumeGroupContentStatus) DeepCopy() *VolumeGroupContentStatus {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VolumeGroupError) DeepCopyInto(out *VolumeGroupError) {
-	*out = *in
-	if in.Time != nil {
-		in, out := &in.Time, &out.Time
-		*out = (*in).DeepCopy()
-	}
-	if in.Message != nil {
-		in, out := &in.Message, &out.Message
-		*out = new(string)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupError.
-func (in *VolumeGroupError) DeepCopy() *VolumeGroupError {
-	if in == nil {
-		return nil
-	}
-	out := new(VolumeGroupError)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeGroupList) DeepCopyInto(out *VolumeGroupList) {
 	*out = *in
@@ -359,6 +342,11 @@ func (in *VolumeGroupSource) DeepCopyInto(out *VolumeGroupSource) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PersistentVolumeClaimNames != nil {
+		in, out := &in.PersistentVolumeClaimNames, &out.PersistentVolumeClaimNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupSource.
@@ -411,15 +399,12 @@ func (in *VolumeGroupStatus) DeepCopyInto(out *VolumeGroupStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Ready != nil {
-		in, out := &in.Ready, &out.Ready
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Error != nil {
-		in, out := &in.Error, &out.Error
-		*out = new(VolumeGroupError)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
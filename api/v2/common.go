@@ -0,0 +1,90 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// VolumeGroupDeletionPolicy describes a policy for end-of-life maintenance of
+// volume group contents
+type VolumeGroupDeletionPolicy string
+
+const (
+	// VolumeGroupContentDelete means the group will be deleted from the
+	// underlying storage system on release from its volume group.
+	VolumeGroupContentDelete VolumeGroupDeletionPolicy = "Delete"
+
+	// VolumeGroupContentRetain means the group will be left in its current
+	// state on release from its volume group.
+	VolumeGroupContentRetain VolumeGroupDeletionPolicy = "Retain"
+)
+
+// VolumeGroupMember refers to a persistent volume claim that is a member of
+// the group and to the persistent volume it is bound to
+type VolumeGroupMember struct {
+	// Name of the persistent volume claim
+	Name string `json:"name"`
+
+	// Namespace of the persistent volume claim
+	Namespace string `json:"namespace"`
+
+	// UID of the persistent volume claim
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+
+	// PersistentVolumeName is the name of the persistent volume bound to the claim
+	// +optional
+	PersistentVolumeName string `json:"persistentVolumeName,omitempty"`
+
+	// VolumeHandle is the CSI volume ID of the persistent volume
+	// +optional
+	VolumeHandle string `json:"volumeHandle,omitempty"`
+
+	// AddedTime is the time the volume was added to the group
+	// +optional
+	AddedTime *metav1.Time `json:"addedTime,omitempty"`
+}
+
+// Condition types reported in the status of VolumeGroup and VolumeGroupContent objects
+const (
+	// ConditionReady is True when the group is bound, its membership is in sync
+	// with the storage system and nothing blocks it.
+	ConditionReady = "Ready"
+
+	// ConditionBound is True when the group is bound to its counterpart
+	// VolumeGroup or VolumeGroupContent.
+	ConditionBound = "Bound"
+
+	// ConditionMembershipSynced is True when the last membership change of the
+	// group was applied on the storage system.
+	ConditionMembershipSynced = "MembershipSynced"
+
+	// ConditionDriverReachable is False when the last call to the CSI driver
+	// failed because the driver could not be reached.
+	ConditionDriverReachable = "DriverReachable"
+
+	// ConditionDeletionBlocked is True when the deletion of the group cannot
+	// complete.
+	ConditionDeletionBlocked = "DeletionBlocked"
+
+	// ConditionReconciled is False when the last reconcile of the group failed
+	// for a reason that is not reported by the other conditions. It does not
+	// affect Ready.
+	ConditionReconciled = "Reconciled"
+)
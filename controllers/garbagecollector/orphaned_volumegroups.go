@@ -0,0 +1,221 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// volumeGroupNameRegex matches the names that the volumeGroup controller gives to the groups
+// it creates on the storage, volumegroup-<VolumeGroup UID>. It matches whole names only, a group
+// whose name merely contains such a name was not created by the operator.
+var volumeGroupNameRegex = regexp.MustCompile("^" + regexp.QuoteMeta(utils.VolumeGroupNamePrefix) +
+	"-([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$")
+
+// OrphanedVolumeGroupsCollector looks for groups on the storage that were created for a
+// volumeGroup that no longer exists and that no volumeGroupContent refers to. That happens
+// when the operator stops between creating the group and creating its volumeGroupContent.
+// It runs on the leader only.
+type OrphanedVolumeGroupsCollector struct {
+	// Reader should read from the API server, a stale cache could make a new group look orphaned.
+	Reader             client.Reader
+	Client             client.Client
+	Log                logr.Logger
+	DriverConfig       *config.DriverConfig
+	GRPCClients        map[string]*grpcClient.Client
+	VolumeGroupClients map[string]grpcClient.VolumeGroup
+
+	// suspects holds the groups of every driver that were orphaned in the previous pass, a group
+	// is only deleted when it is found orphaned twice in a row.
+	suspects map[string]map[string]bool
+}
+
+type storageVolumeGroup struct {
+	driver      string
+	volumeGroup *csi.VolumeGroup
+	secrets     map[string]string
+}
+
+func (c *OrphanedVolumeGroupsCollector) SetupWithManager(mgr ctrl.Manager) error {
+	c.VolumeGroupClients = grpcClient.NewVolumeGroupClients(c.GRPCClients, c.DriverConfig.RPCTimeout)
+	return mgr.Add(c)
+}
+
+func (c *OrphanedVolumeGroupsCollector) NeedLeaderElection() bool {
+	return true
+}
+
+func (c *OrphanedVolumeGroupsCollector) Start(ctx context.Context) error {
+	if c.DriverConfig.OrphanedVolumeGroupsPolicy == config.OrphanedVolumeGroupsDisabled {
+		return nil
+	}
+	c.suspects = map[string]map[string]bool{}
+	wait.UntilWithContext(ctx, c.collect, c.DriverConfig.OrphanedVolumeGroupsInterval)
+	return nil
+}
+
+func (c *OrphanedVolumeGroupsCollector) collect(ctx context.Context) {
+	for _, driver := range c.DriverConfig.GetDriverNames() {
+		logger := c.Log.WithValues("Policy", c.DriverConfig.OrphanedVolumeGroupsPolicy, "Driver", driver)
+		if utils.IsDriverDisconnected(logger, c.GRPCClients[driver]) {
+			continue
+		}
+		c.collectDriver(ctx, logger, driver)
+	}
+}
+
+func (c *OrphanedVolumeGroupsCollector) collectDriver(ctx context.Context, logger logr.Logger, driver string) {
+	storageVolumeGroups, err := c.listStorageVolumeGroups(ctx, logger, driver)
+	if err != nil {
+		return
+	}
+	volumeGroupUIDs, err := c.getVolumeGroupUIDs(ctx)
+	if err != nil {
+		logger.Error(err, messages.FailedToListVolumeGroups)
+		return
+	}
+	volumeGroupHandles, err := c.getVolumeGroupHandles(ctx, driver)
+	if err != nil {
+		logger.Error(err, messages.FailedToListVolumeGroupContents)
+		return
+	}
+
+	suspects := map[string]bool{}
+	for _, storageVG := range storageVolumeGroups {
+		volumeGroupId := storageVG.volumeGroup.GetVolumeGroupId()
+		uid := getVolumeGroupUID(storageVG.volumeGroup)
+		if uid == "" || volumeGroupUIDs[types.UID(uid)] || volumeGroupHandles[volumeGroupId] {
+			continue
+		}
+		logger.Info(fmt.Sprintf(messages.OrphanedVolumeGroupFound, volumeGroupId, uid))
+		if c.DriverConfig.OrphanedVolumeGroupsPolicy != config.OrphanedVolumeGroupsDelete {
+			continue
+		}
+		if !c.suspects[driver][volumeGroupId] {
+			suspects[volumeGroupId] = true
+			continue
+		}
+		if err := c.deleteStorageVolumeGroup(ctx, logger, storageVG); err != nil {
+			suspects[volumeGroupId] = true
+		}
+	}
+	c.suspects[driver] = suspects
+}
+
+func (c *OrphanedVolumeGroupsCollector) listStorageVolumeGroups(ctx context.Context, logger logr.Logger,
+	driver string) ([]storageVolumeGroup, error) {
+	secretsList, err := utils.GetVolumeGroupClassesSecrets(ctx, c.Client, logger, driver)
+	if err != nil {
+		return nil, err
+	}
+
+	storageVolumeGroups := []storageVolumeGroup{}
+	seenVolumeGroupIds := map[string]bool{}
+	for _, secrets := range secretsList {
+		volumeGroups, err := utils.ListStorageVolumeGroups(ctx, logger, c.VolumeGroupClients[driver], secrets)
+		if status.Code(err) == codes.Unimplemented {
+			logger.Info(messages.VolumeGroupListIsNotSupported)
+			return nil, err
+		}
+		if err != nil {
+			logger.Error(err, messages.FailedToListStorageVolumeGroups)
+			return nil, err
+		}
+		for _, vg := range volumeGroups {
+			if seenVolumeGroupIds[vg.GetVolumeGroupId()] {
+				continue
+			}
+			seenVolumeGroupIds[vg.GetVolumeGroupId()] = true
+			storageVolumeGroups = append(storageVolumeGroups, storageVolumeGroup{driver: driver, volumeGroup: vg, secrets: secrets})
+		}
+	}
+	return storageVolumeGroups, nil
+}
+
+func (c *OrphanedVolumeGroupsCollector) getVolumeGroupUIDs(ctx context.Context) (map[types.UID]bool, error) {
+	vgList := &volumegroupv2.VolumeGroupList{}
+	if err := c.Reader.List(ctx, vgList); err != nil {
+		return nil, err
+	}
+	uids := map[types.UID]bool{}
+	for _, vg := range vgList.Items {
+		uids[vg.UID] = true
+	}
+	return uids, nil
+}
+
+func (c *OrphanedVolumeGroupsCollector) getVolumeGroupHandles(ctx context.Context, driver string) (map[string]bool, error) {
+	vgcList := &volumegroupv2.VolumeGroupContentList{}
+	if err := c.Reader.List(ctx, vgcList); err != nil {
+		return nil, err
+	}
+	handles := map[string]bool{}
+	for _, vgc := range vgcList.Items {
+		if vgc.Spec.Source != nil && vgc.Spec.Source.Driver == driver {
+			handles[vgc.Spec.Source.VolumeGroupHandle] = true
+		}
+	}
+	return handles, nil
+}
+
+func (c *OrphanedVolumeGroupsCollector) deleteStorageVolumeGroup(ctx context.Context, logger logr.Logger, storageVG storageVolumeGroup) error {
+	volumeGroupId := storageVG.volumeGroup.GetVolumeGroupId()
+	logger.Info(fmt.Sprintf(messages.DeleteOrphanedVolumeGroup, volumeGroupId))
+	params := volumegroup.CommonRequestParameters{
+		VolumeGroupID: volumeGroupId,
+		Secrets:       storageVG.secrets,
+		VolumeGroup:   c.VolumeGroupClients[storageVG.driver],
+	}
+	deleteVolumeGroupResponse := volumegroup.NewVolumeGroupRequest(params).Delete(ctx)
+	if deleteVolumeGroupResponse.Error != nil && !deleteVolumeGroupResponse.HasKnownGRPCError([]codes.Code{codes.NotFound}) {
+		logger.Error(deleteVolumeGroupResponse.Error, fmt.Sprintf(messages.FailedToDeleteOrphanedVolumeGroup, volumeGroupId))
+		return deleteVolumeGroupResponse.Error
+	}
+	logger.Info(fmt.Sprintf(messages.DeletedOrphanedVolumeGroup, volumeGroupId))
+	return nil
+}
+
+// getVolumeGroupUID returns the volumeGroup UID from the name of the group, drivers that do not use
+// the name as the group ID may return it in the group context.
+func getVolumeGroupUID(vg *csi.VolumeGroup) string {
+	if match := volumeGroupNameRegex.FindStringSubmatch(vg.GetVolumeGroupId()); match != nil {
+		return match[1]
+	}
+	for _, value := range vg.GetVolumeGroupContext() {
+		if match := volumeGroupNameRegex.FindStringSubmatch(value); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
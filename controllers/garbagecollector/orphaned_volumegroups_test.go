@@ -0,0 +1,67 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"testing"
+
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+)
+
+const testVolumeGroupUID = "0b1c2d3e-4f50-6172-8394-a5b6c7d8e9f0"
+
+// TestGetVolumeGroupUID checks that only the groups the operator named are traced back to a
+// volumeGroup, foreign groups whose names contain such a name must never be collected.
+func TestGetVolumeGroupUID(t *testing.T) {
+	tests := []struct {
+		name        string
+		vg          *csi.VolumeGroup
+		expectedUID string
+	}{
+		{
+			name:        "group named by the operator",
+			vg:          &csi.VolumeGroup{VolumeGroupId: "volumegroup-" + testVolumeGroupUID},
+			expectedUID: testVolumeGroupUID,
+		},
+		{
+			name: "group name in the group context",
+			vg: &csi.VolumeGroup{VolumeGroupId: "1234",
+				VolumeGroupContext: map[string]string{"name": "volumegroup-" + testVolumeGroupUID}},
+			expectedUID: testVolumeGroupUID,
+		},
+		{
+			name: "foreign group with a prefix before the name",
+			vg:   &csi.VolumeGroup{VolumeGroupId: "backup-volumegroup-" + testVolumeGroupUID},
+		},
+		{
+			name: "foreign group with a suffix after the name",
+			vg:   &csi.VolumeGroup{VolumeGroupId: "volumegroup-" + testVolumeGroupUID + "-copy"},
+		},
+		{
+			name: "foreign group context containing the name",
+			vg: &csi.VolumeGroup{VolumeGroupId: "1234",
+				VolumeGroupContext: map[string]string{"description": "copy of volumegroup-" + testVolumeGroupUID}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if uid := getVolumeGroupUID(tt.vg); uid != tt.expectedUID {
+				t.Errorf("expected UID %q, got %q", tt.expectedUID, uid)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolumeclaim
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+// membershipBatcher gathers the persistentVolumeClaims to add to and remove from every volumeGroup
+// for a window, and then changes the membership of the group with a single request to the driver.
+// Batches that fail are retried with backoff together with the changes queued in the meantime.
+type membershipBatcher struct {
+	log    logr.Logger
+	window time.Duration
+	flush  func(ctx context.Context, vgKey types.NamespacedName, batch *membershipBatch) error
+	queue  workqueue.RateLimitingInterface
+
+	mu      sync.Mutex
+	batches map[types.NamespacedName]*membershipBatch
+}
+
+// membershipBatch holds the pending membership changes of a volumeGroup.
+type membershipBatch struct {
+	driver  string
+	adds    map[types.NamespacedName]bool
+	removes map[types.NamespacedName]bool
+}
+
+func newMembershipBatcher(log logr.Logger, window time.Duration, rateLimiter ratelimiter.RateLimiter,
+	flush func(ctx context.Context, vgKey types.NamespacedName, batch *membershipBatch) error) *membershipBatcher {
+	return &membershipBatcher{
+		log:     log,
+		window:  window,
+		flush:   flush,
+		queue:   workqueue.NewNamedRateLimitingQueue(rateLimiter, "volumegroup-membership"),
+		batches: map[types.NamespacedName]*membershipBatch{},
+	}
+}
+
+// add queues the persistentVolumeClaim to be added to the volumeGroup, it replaces a queued removal.
+func (b *membershipBatcher) add(vgKey types.NamespacedName, driver string, pvcKey types.NamespacedName) {
+	b.submit(vgKey, driver, func(batch *membershipBatch) {
+		delete(batch.removes, pvcKey)
+		batch.adds[pvcKey] = true
+	})
+}
+
+// remove queues the persistentVolumeClaim to be removed from the volumeGroup, it replaces a queued addition.
+func (b *membershipBatcher) remove(vgKey types.NamespacedName, driver string, pvcKey types.NamespacedName) {
+	b.submit(vgKey, driver, func(batch *membershipBatch) {
+		delete(batch.adds, pvcKey)
+		batch.removes[pvcKey] = true
+	})
+}
+
+func (b *membershipBatcher) submit(vgKey types.NamespacedName, driver string, change func(batch *membershipBatch)) {
+	b.mu.Lock()
+	batch, ok := b.batches[vgKey]
+	if !ok {
+		batch = newMembershipBatch(driver)
+		b.batches[vgKey] = batch
+	}
+	change(batch)
+	b.mu.Unlock()
+
+	// the queue keeps the earliest time of a key, the batch is flushed a window after its first change
+	b.queue.AddAfter(vgKey, b.window)
+}
+
+func newMembershipBatch(driver string) *membershipBatch {
+	return &membershipBatch{
+		driver:  driver,
+		adds:    map[types.NamespacedName]bool{},
+		removes: map[types.NamespacedName]bool{},
+	}
+}
+
+func (b *membershipBatcher) NeedLeaderElection() bool {
+	return true
+}
+
+func (b *membershipBatcher) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		b.queue.ShutDown()
+	}()
+	for b.processNextBatch(ctx) {
+	}
+	return nil
+}
+
+func (b *membershipBatcher) processNextBatch(ctx context.Context) bool {
+	item, shutdown := b.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer b.queue.Done(item)
+	vgKey := item.(types.NamespacedName)
+
+	b.mu.Lock()
+	batch := b.batches[vgKey]
+	delete(b.batches, vgKey)
+	b.mu.Unlock()
+	if batch == nil {
+		b.queue.Forget(item)
+		return true
+	}
+
+	logger := b.log.WithValues("VolumeGroup", vgKey.String())
+	if err := utils.IgnoreTerminalDriverError(logger, b.flush(ctx, vgKey, batch)); err != nil {
+		b.requeue(vgKey, batch)
+		b.queue.AddRateLimited(item)
+		return true
+	}
+	b.queue.Forget(item)
+	return true
+}
+
+// requeue returns the changes of a failed batch to the queue, changes of the same persistentVolumeClaims
+// that were queued after the batch was taken are kept.
+func (b *membershipBatcher) requeue(vgKey types.NamespacedName, failed *membershipBatch) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	batch, ok := b.batches[vgKey]
+	if !ok {
+		batch = newMembershipBatch(failed.driver)
+		b.batches[vgKey] = batch
+	}
+	for pvcKey := range failed.adds {
+		if !batch.removes[pvcKey] {
+			batch.adds[pvcKey] = true
+		}
+	}
+	for pvcKey := range failed.removes {
+		if !batch.adds[pvcKey] {
+			batch.removes[pvcKey] = true
+		}
+	}
+}
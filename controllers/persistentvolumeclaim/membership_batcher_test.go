@@ -0,0 +1,339 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolumeclaim
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	csiv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	csiv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testDriver           = "driver.example.com"
+	testNamespace        = "default"
+	testStorageClass     = "storage-class"
+	testVolumeGroupClass = "volume-group-class"
+	testVolumeGroup      = "volume-group"
+	testVolumeGroupID    = "volume-group-id"
+)
+
+// countingVolumeGroupClient counts the membership requests that reach the driver.
+type countingVolumeGroupClient struct {
+	mu          sync.Mutex
+	modifyCalls int
+	volumeIds   []string
+}
+
+func (c *countingVolumeGroupClient) CreateVolumeGroup(context.Context, string, map[string]string, map[string]string) (*csi.CreateVolumeGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "")
+}
+
+func (c *countingVolumeGroupClient) DeleteVolumeGroup(context.Context, string, map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "")
+}
+
+func (c *countingVolumeGroupClient) ModifyVolumeGroupMembership(_ context.Context, _ string, volumeIds []string,
+	_ map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.modifyCalls++
+	c.volumeIds = volumeIds
+	return &csi.ModifyVolumeGroupMembershipResponse{}, nil
+}
+
+func (c *countingVolumeGroupClient) ControllerGetVolumeGroup(context.Context, string, map[string]string) (*csi.ControllerGetVolumeGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "")
+}
+
+func (c *countingVolumeGroupClient) ListVolumeGroups(context.Context, int32, string, map[string]string) (*csi.ListVolumeGroupsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "")
+}
+
+// TestLabelledPVCsAreAddedWithOneModifyRequestPerWindow labels many persistentVolumeClaims for one
+// volumeGroup while the batcher runs, without batching every reconcile sent its own request with the
+// growing list of volumes. The claims labelled after the first batch is flushed make a second batch.
+func TestLabelledPVCsAreAddedWithOneModifyRequestPerWindow(t *testing.T) {
+	const pvcCount = 50
+	const firstBatchCount = pvcCount / 2
+	const window = time.Second
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	k8sClient := newFakeClient(t, pvcCount)
+	vgClient := &countingVolumeGroupClient{}
+	r := newTestReconciler(t, k8sClient, vgClient, window)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		_ = r.membershipBatcher.Start(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+
+	reconcileConcurrently(ctx, t, r, 0, firstBatchCount)
+	waitForMembers(ctx, t, k8sClient, firstBatchCount)
+	assertModifyRequests(t, vgClient, 1, firstBatchCount)
+
+	reconcileConcurrently(ctx, t, r, firstBatchCount, pvcCount)
+	waitForMembers(ctx, t, k8sClient, pvcCount)
+	assertModifyRequests(t, vgClient, 2, pvcCount)
+}
+
+// reconcileConcurrently reconciles the persistentVolumeClaims from first up to last in parallel, the
+// way the workers of the controller do.
+func reconcileConcurrently(ctx context.Context, t *testing.T, r *PersistentVolumeClaimReconciler, first, last int) {
+	var wg sync.WaitGroup
+	errs := make(chan error, last-first)
+	for i := first; i < last; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: pvcName(i), Namespace: testNamespace}}
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				errs <- fmt.Errorf("reconcile of %s failed: %w", request, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+// waitForMembers waits for the volumeGroup and its volumeGroupContent to have memberCount members.
+func waitForMembers(ctx context.Context, t *testing.T, k8sClient client.Client, memberCount int) {
+	vg := &csiv2.VolumeGroup{}
+	vgc := &csiv2.VolumeGroupContent{}
+	err := wait.PollImmediate(10*time.Millisecond, 10*time.Second, func() (bool, error) {
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: testVolumeGroup, Namespace: testNamespace}, vg); err != nil {
+			return false, err
+		}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: testVolumeGroup}, vgc); err != nil {
+			return false, err
+		}
+		return len(vg.Status.Members) == memberCount && len(vgc.Status.Members) == memberCount, nil
+	})
+	if err != nil {
+		t.Fatalf("volumeGroup has %d persistentVolumeClaims and volumeGroupContent has %d persistentVolumes, expected %d: %v",
+			len(vg.Status.Members), len(vgc.Status.Members), memberCount, err)
+	}
+}
+
+func assertModifyRequests(t *testing.T, vgClient *countingVolumeGroupClient, modifyCalls, volumeCount int) {
+	vgClient.mu.Lock()
+	defer vgClient.mu.Unlock()
+	if vgClient.modifyCalls != modifyCalls {
+		t.Errorf("expected %d ModifyVolumeGroupMembership requests, got %d", modifyCalls, vgClient.modifyCalls)
+	}
+	if len(vgClient.volumeIds) != volumeCount {
+		t.Errorf("expected the last request to have %d volumes, got %d", volumeCount, len(vgClient.volumeIds))
+	}
+}
+
+func newTestReconciler(t *testing.T, k8sClient client.Client, vgClient grpcClient.VolumeGroup,
+	window time.Duration) *PersistentVolumeClaimReconciler {
+	// the driver calls go to vgClient, the connection only has to be ready for the driver to count as connected
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.Dial("passthrough:///driver", grpc.WithBlock(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	cfg := config.NewDriverConfig()
+	cfg.DriverName = testDriver
+	cfg.DriverEndpoint = "unused"
+	cfg.MultipleVGsToPVC = "true"
+	r := &PersistentVolumeClaimReconciler{
+		Client:             k8sClient,
+		APIReader:          k8sClient,
+		Scheme:             k8sClient.Scheme(),
+		Log:                logr.Discard(),
+		DriverConfig:       cfg,
+		GRPCClients:        map[string]*grpcClient.Client{testDriver: {Client: conn}},
+		VolumeGroupClients: map[string]grpcClient.VolumeGroup{testDriver: vgClient},
+	}
+	r.membershipBatcher = newMembershipBatcher(logr.Discard(), window, workqueue.DefaultControllerRateLimiter(), r.flushMembershipBatch)
+	return r
+}
+
+func newFakeClient(t *testing.T, pvcCount int) client.Client {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := csiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := csiv2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	vgClassName := testVolumeGroupClass
+	vgcName := testVolumeGroup
+	storageClassName := testStorageClass
+	objects := []client.Object{
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: testStorageClass}, Provisioner: testDriver},
+		&csiv1.VolumeGroupClass{ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroupClass}, Driver: testDriver},
+		&csiv2.VolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroup, Namespace: testNamespace},
+			Spec: csiv2.VolumeGroupSpec{
+				VolumeGroupClassName: &vgClassName,
+				Source: csiv2.VolumeGroupSource{
+					VolumeGroupContentName: &vgcName,
+					Selector:               &metav1.LabelSelector{MatchLabels: map[string]string{"group": testVolumeGroup}},
+				},
+			},
+		},
+		&csiv2.VolumeGroupContent{
+			ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroup},
+			Spec: csiv2.VolumeGroupContentSpec{
+				VolumeGroupClassName: &vgClassName,
+				Source:               &csiv2.VolumeGroupContentSource{Driver: testDriver, VolumeGroupHandle: testVolumeGroupID},
+			},
+		},
+	}
+	for i := 0; i < pvcCount; i++ {
+		objects = append(objects,
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: pvcName(i), Namespace: testNamespace,
+					Labels: map[string]string{"group": testVolumeGroup}},
+				Spec:   corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClassName, VolumeName: pvName(i)},
+				Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: pvName(i)},
+				Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{Driver: testDriver, VolumeHandle: fmt.Sprintf("volume-%d", i)},
+				}},
+			})
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func pvcName(i int) string {
+	return fmt.Sprintf("pvc-%d", i)
+}
+
+func pvName(i int) string {
+	return fmt.Sprintf("pv-%d", i)
+}
+
+// TestFlushChecksQueuedPVCsAgain changes a queued persistentVolumeClaim during the batch window, the flush
+// must not send it to the driver once it can no longer be added.
+func TestFlushChecksQueuedPVCsAgain(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, t *testing.T, k8sClient client.Client)
+	}{
+		{
+			name: "claim no longer bound",
+			change: func(ctx context.Context, t *testing.T, k8sClient client.Client) {
+				pvc := &corev1.PersistentVolumeClaim{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: pvcName(0), Namespace: testNamespace}, pvc); err != nil {
+					t.Fatal(err)
+				}
+				pvc.Status.Phase = corev1.ClaimLost
+				if err := k8sClient.Update(ctx, pvc); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "claim added to another volumeGroup",
+			change: func(ctx context.Context, t *testing.T, k8sClient client.Client) {
+				vgClassName := testVolumeGroupClass
+				otherVG := &csiv2.VolumeGroup{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-volume-group", Namespace: testNamespace},
+					Spec: csiv2.VolumeGroupSpec{
+						VolumeGroupClassName: &vgClassName,
+						Source:               csiv2.VolumeGroupSource{PersistentVolumeClaimNames: []string{pvcName(0)}},
+					},
+				}
+				if err := k8sClient.Create(ctx, otherVG); err != nil {
+					t.Fatal(err)
+				}
+				otherVG.Status.Members = []csiv2.VolumeGroupMember{{Name: pvcName(0), Namespace: testNamespace}}
+				if err := k8sClient.Status().Update(ctx, otherVG); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := newFakeClient(t, 1)
+			vgClient := &countingVolumeGroupClient{}
+			r := newTestReconciler(t, k8sClient, vgClient, 0)
+			r.DriverConfig.MultipleVGsToPVC = "false"
+
+			batch := newMembershipBatch(testDriver)
+			batch.adds[types.NamespacedName{Name: pvcName(0), Namespace: testNamespace}] = true
+			tt.change(ctx, t, k8sClient)
+			vgKey := types.NamespacedName{Name: testVolumeGroup, Namespace: testNamespace}
+			if err := r.flushMembershipBatch(ctx, vgKey, batch); err != nil {
+				t.Fatal(err)
+			}
+
+			assertModifyRequests(t, vgClient, 0, 0)
+			vg := &csiv2.VolumeGroup{}
+			if err := k8sClient.Get(ctx, vgKey, vg); err != nil {
+				t.Fatal(err)
+			}
+			if len(vg.Status.Members) != 0 {
+				t.Errorf("expected the claim to be dropped from the batch, volumeGroup has members %+v", vg.Status.Members)
+			}
+		})
+	}
+}
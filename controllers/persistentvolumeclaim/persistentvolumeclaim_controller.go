@@ -19,36 +19,58 @@ package persistentvolumeclaim
 import (
 	"context"
 	"fmt"
+	"sort"
 
-	csiv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	csiv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 	"github.com/IBM/csi-volume-group-operator/controllers/utils"
 	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"github.com/IBM/csi-volume-group-operator/pkg/tracing"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 type PersistentVolumeClaimReconciler struct {
-	Client            client.Client
-	Scheme            *runtime.Scheme
-	Log               logr.Logger
-	DriverConfig      *config.DriverConfig
-	GRPCClient        *grpcClient.Client
-	VolumeGroupClient grpcClient.VolumeGroup
+	Client       client.Client
+	Scheme       *runtime.Scheme
+	Log          logr.Logger
+	DriverConfig *config.DriverConfig
+	// GRPCClients and VolumeGroupClients hold the clients of every served driver by driver name.
+	GRPCClients        map[string]*grpcClient.Client
+	VolumeGroupClients map[string]grpcClient.VolumeGroup
+	// APIReader reads the volumeGroups before their membership is changed, bypassing the cache.
+	APIReader client.Reader
+
+	membershipBatcher *membershipBatcher
 }
 
-func (r *PersistentVolumeClaimReconciler) Reconcile(_ context.Context, req reconcile.Request) (result reconcile.Result, err error) {
-	result = reconcile.Result{}
+func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues(messages.RequestNamespace, req.Namespace, messages.RequestName, req.Name)
 	reqLogger.Info(messages.ReconcilePersistentVolumeClaim)
-	pvc, err := utils.GetPersistentVolumeClaim(reqLogger, r.Client, req.Name, req.Namespace)
+
+	ctx, span := tracing.StartReconcileSpan(ctx, persistentVolumeClaimController, req)
+	result, err := r.reconcilePersistentVolumeClaim(ctx, reqLogger, req)
+	tracing.EndSpan(span, err)
+	metrics.RecordReconcileOutcome(persistentVolumeClaimController, result, err)
+	return result, utils.IgnoreTerminalDriverError(reqLogger, err)
+}
+
+func (r *PersistentVolumeClaimReconciler) reconcilePersistentVolumeClaim(ctx context.Context, reqLogger logr.Logger,
+	req reconcile.Request) (result reconcile.Result, err error) {
+	result = reconcile.Result{}
+	pvc, err := utils.GetPersistentVolumeClaim(ctx, reqLogger, r.Client, req.Name, req.Namespace)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return result, nil
@@ -56,19 +78,29 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(_ context.Context, req recon
 		return result, err
 	}
 
-	isPVCNeedToBeHandled, err := r.isPVCNeedToBeHandled(reqLogger, pvc)
+	driver, err := utils.GetPVCDriver(ctx, reqLogger, r.Client, pvc)
+	if err != nil {
+		return result, err
+	}
+	if !r.DriverConfig.IsDriverServed(driver) {
+		return result, nil
+	}
+	isPVCNeedToBeHandled, err := r.isPVCNeedToBeHandled(ctx, reqLogger, pvc)
 	if err != nil {
 		return result, err
 	}
 	if !isPVCNeedToBeHandled {
 		return result, nil
 	}
+	if utils.IsDriverDisconnected(reqLogger, r.GRPCClients[driver]) {
+		return reconcile.Result{RequeueAfter: utils.DriverDisconnectedRequeueInterval}, nil
+	}
 
-	err = r.removePersistentVolumeClaimFromVolumeGroupObjects(reqLogger, pvc)
+	err = r.removePersistentVolumeClaimFromVolumeGroupObjects(ctx, reqLogger, pvc, driver)
 	if err != nil {
 		return result, err
 	}
-	err = r.addPersistentVolumeClaimToVolumeGroupObjects(reqLogger, pvc)
+	err = r.addPersistentVolumeClaimToVolumeGroupObjects(ctx, reqLogger, pvc, driver)
 	if err != nil {
 		return result, err
 	}
@@ -76,19 +108,12 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(_ context.Context, req recon
 	return result, nil
 }
 
-func (r *PersistentVolumeClaimReconciler) isPVCNeedToBeHandled(reqLogger logr.Logger, pvc *corev1.PersistentVolumeClaim) (bool, error) {
-	isPVCHasMatchingDriver, err := utils.IsPVCHasMatchingDriver(reqLogger, r.Client, pvc, r.DriverConfig.DriverName)
-	if err != nil {
-		return false, err
-	}
-	if !isPVCHasMatchingDriver {
-		return false, nil
-	}
+func (r *PersistentVolumeClaimReconciler) isPVCNeedToBeHandled(ctx context.Context, reqLogger logr.Logger, pvc *corev1.PersistentVolumeClaim) (bool, error) {
 	if pvc.Status.Phase != corev1.ClaimBound {
 		reqLogger.Info(messages.PersistentVolumeClaimIsNotInBoundPhase)
 		return false, nil
 	}
-	isSCHasVGParam, err := utils.IsPVCInStaticVG(reqLogger, r.Client, pvc)
+	isSCHasVGParam, err := utils.IsPVCInStaticVG(ctx, reqLogger, r.Client, pvc)
 	if err != nil {
 		return false, err
 	}
@@ -100,7 +125,7 @@ func (r *PersistentVolumeClaimReconciler) isPVCNeedToBeHandled(reqLogger logr.Lo
 		msg := fmt.Sprintf(messages.StorageClassHasVGParameter, storageClassName, pvc.Namespace, pvc.Name)
 		reqLogger.Info(msg)
 		mErr := fmt.Errorf(msg)
-		err = utils.HandlePVCErrorMessage(reqLogger, r.Client, pvc, mErr, addingPVC)
+		err = utils.HandlePVCErrorMessage(ctx, reqLogger, r.Client, pvc, mErr, addingPVC)
 		if err != nil {
 			return false, err
 		}
@@ -109,61 +134,56 @@ func (r *PersistentVolumeClaimReconciler) isPVCNeedToBeHandled(reqLogger logr.Lo
 	return true, nil
 }
 
-func (r PersistentVolumeClaimReconciler) removePersistentVolumeClaimFromVolumeGroupObjects(
-	logger logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
-	vgList, err := utils.GetVGList(logger, r.Client, r.DriverConfig.DriverName)
+func (r PersistentVolumeClaimReconciler) removePersistentVolumeClaimFromVolumeGroupObjects(ctx context.Context,
+	logger logr.Logger, pvc *corev1.PersistentVolumeClaim, driver string) error {
+	vgList, err := utils.GetVGListOfPVC(ctx, logger, r.Client, pvc)
 	if err != nil {
 		return err
 	}
 
 	for _, vg := range vgList.Items {
-		if !utils.IsPVCPartOfVG(pvc, vg.Status.PVCList) {
+		if !utils.IsPVCPartOfVG(pvc, vg.Status.Members) {
 			continue
 		}
-		IsPVCMatchesVG, err := utils.IsPVCMatchesVG(logger, r.Client, pvc, vg)
+		IsPVCMatchesVG, err := utils.IsPVCMatchesVG(ctx, logger, r.Client, pvc, vg)
 		if err != nil {
-			return utils.HandleErrorMessage(logger, r.Client, &vg, err, removingPVC)
+			return utils.HandleErrorMessage(ctx, logger, r.Client, &vg, err, removingPVC)
 		}
 
 		if !IsPVCMatchesVG {
-			err := utils.RemoveVolumeFromVolumeGroup(logger, r.Client, r.VolumeGroupClient,
-				[]corev1.PersistentVolumeClaim{*pvc}, &vg)
-			if err != nil {
-				return utils.HandleErrorMessage(logger, r.Client, &vg, err, removingPVC)
-			}
-			err = utils.RemoveVolumeFromPvcListAndPvList(logger, r.Client, r.DriverConfig.DriverName, pvc, vg)
-			return utils.HandleErrorMessage(logger, r.Client, &vg, err, removingPVC)
+			logger.Info(fmt.Sprintf(messages.QueueRemovePersistentVolumeClaimFromVolumeGroup, pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+			r.membershipBatcher.remove(client.ObjectKeyFromObject(&vg), driver, client.ObjectKeyFromObject(pvc))
+			return nil
 		}
 	}
 	return nil
 }
 
-func (r PersistentVolumeClaimReconciler) addPersistentVolumeClaimToVolumeGroupObjects(
-	logger logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
+func (r PersistentVolumeClaimReconciler) addPersistentVolumeClaimToVolumeGroupObjects(ctx context.Context,
+	logger logr.Logger, pvc *corev1.PersistentVolumeClaim, driver string) error {
 	var err error
-	vgList, err := utils.GetVGList(logger, r.Client, r.DriverConfig.DriverName)
+	vgList, err := utils.GetVGList(ctx, logger, r.Client, driver)
 	if err != nil {
 		return err
 	}
-	err = r.isPVCCanBeAddedToVG(logger, pvc, vgList)
+	err = r.isPVCCanBeAddedToVG(ctx, logger, pvc, vgList)
 	if err != nil {
 		return err
 	}
 
 	for _, vg := range vgList.Items {
-		if !utils.IsPVCPartOfVG(pvc, vg.Status.PVCList) {
-			isPVCMatchesVG, err := utils.IsPVCMatchesVG(logger, r.Client, pvc, vg)
+		if !utils.IsPVCPartOfVG(pvc, vg.Status.Members) {
+			isPVCMatchesVG, err := utils.IsPVCMatchesVG(ctx, logger, r.Client, pvc, vg)
 			if err != nil {
-				return utils.HandleErrorMessage(logger, r.Client, &vg, err, addingPVC)
+				return utils.HandleErrorMessage(ctx, logger, r.Client, &vg, err, addingPVC)
 			}
 			if isPVCMatchesVG {
-				err := utils.AddVolumesToVolumeGroup(logger, r.Client, r.VolumeGroupClient,
-					[]corev1.PersistentVolumeClaim{*pvc}, &vg)
-				if err != nil {
-					return utils.HandleErrorMessage(logger, r.Client, &vg, err, addingPVC)
+				if err = utils.ValidatePVCVolumeIsCSI(ctx, logger, r.Client, pvc); err != nil {
+					return r.handleNotCSIVolume(ctx, logger, pvc, err)
 				}
-				err = utils.AddVolumeToPvcListAndPvList(logger, r.Client, pvc, &vg)
-				return utils.HandleErrorMessage(logger, r.Client, &vg, err, addingPVC)
+				logger.Info(fmt.Sprintf(messages.QueueAddPersistentVolumeClaimToVolumeGroup, pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+				r.membershipBatcher.add(client.ObjectKeyFromObject(&vg), driver, client.ObjectKeyFromObject(pvc))
+				return nil
 			}
 		}
 
@@ -171,22 +191,171 @@ func (r PersistentVolumeClaimReconciler) addPersistentVolumeClaimToVolumeGroupOb
 	return nil
 }
 
-func (r PersistentVolumeClaimReconciler) isPVCCanBeAddedToVG(logger logr.Logger, pvc *corev1.PersistentVolumeClaim,
-	vgList csiv1.VolumeGroupList) error {
+// handleNotCSIVolume reports claims of in-tree volumes that match a volumeGroup, they are not requeued
+// because their volume does not change.
+func (r PersistentVolumeClaimReconciler) handleNotCSIVolume(ctx context.Context, logger logr.Logger,
+	pvc *corev1.PersistentVolumeClaim, err error) error {
+	if !vgerrors.IsPersistentVolumeNotCSIError(err) {
+		return err
+	}
+	logger.Info(err.Error())
+	return utils.HandlePVCErrorMessage(ctx, logger, r.Client, pvc, err, addingPVC)
+}
+
+// flushMembershipBatch changes the membership of the volumeGroup with all the queued persistentVolumeClaims
+// in a single request to the driver. The claims are checked again against the latest volumeGroup, they may
+// have changed since they were queued or been handled by the volumeGroup controller.
+func (r *PersistentVolumeClaimReconciler) flushMembershipBatch(ctx context.Context, vgKey types.NamespacedName,
+	batch *membershipBatch) error {
+	logger := r.Log.WithValues(messages.RequestNamespace, vgKey.Namespace, messages.RequestName, vgKey.Name)
+	vg := &csiv2.VolumeGroup{ObjectMeta: metav1.ObjectMeta{Name: vgKey.Name, Namespace: vgKey.Namespace}}
+	unlock := utils.LockVolumeGroupMembership(vg)
+	defer unlock()
+	if err := utils.RefreshVolumeGroup(ctx, r.APIReader, vg); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if vg.Spec.Source.VolumeGroupContentName == nil {
+		// the volumeGroup controller adds the matching claims when it binds the group
+		return nil
+	}
+
+	pvcsToAdd := []corev1.PersistentVolumeClaim{}
+	pvcs, err := r.getQueuedPVCs(ctx, batch.adds)
+	if err != nil {
+		return err
+	}
+	// the other volumeGroups of the namespace are read once, batches are flushed one at a time
+	namespaceVGList := csiv2.VolumeGroupList{}
+	if len(pvcs) > 0 && r.DriverConfig.MultipleVGsToPVC != "true" {
+		namespaceVGList, err = utils.GetLatestVGListOfNamespace(ctx, logger, r.APIReader, r.Client, batch.driver, vg.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+	for _, pvc := range pvcs {
+		isPVCMatchesVG, err := utils.IsPVCMatchesVG(ctx, logger, r.Client, &pvc, *vg)
+		if err != nil {
+			return utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, addingPVC)
+		}
+		if !isPVCMatchesVG || utils.IsPVCPartOfVG(&pvc, vg.Status.Members) {
+			continue
+		}
+		isPVCAdmitted, err := r.isQueuedPVCAdmitted(ctx, logger, &pvc, batch.driver, namespaceVGList)
+		if err != nil {
+			return err
+		}
+		if !isPVCAdmitted {
+			logger.Info(fmt.Sprintf(messages.DropQueuedPersistentVolumeClaim, pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+			continue
+		}
+		pvcsToAdd = append(pvcsToAdd, pvc)
+	}
+	pvcsToRemove := []corev1.PersistentVolumeClaim{}
+	pvcs, err = r.getQueuedPVCs(ctx, batch.removes)
+	if err != nil {
+		return err
+	}
+	for _, pvc := range pvcs {
+		isPVCMatchesVG, err := utils.IsPVCMatchesVG(ctx, logger, r.Client, &pvc, *vg)
+		if err != nil {
+			return utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, removingPVC)
+		}
+		if !isPVCMatchesVG && utils.IsPVCPartOfVG(&pvc, vg.Status.Members) {
+			pvcsToRemove = append(pvcsToRemove, pvc)
+		}
+	}
+	if len(pvcsToAdd) == 0 && len(pvcsToRemove) == 0 {
+		return nil
+	}
+
+	err = utils.ModifyVolumeGroupMembership(ctx, logger, r.Client, r.VolumeGroupClients[batch.driver], pvcsToAdd, pvcsToRemove, vg)
+	if err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, modifyingPVCs)
+	}
+	if err = utils.RemoveVolumesFromPvcListAndPvList(ctx, logger, r.Client, batch.driver, pvcsToRemove, vg); err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, removingPVC)
+	}
+	err = utils.AddVolumesToPvcListAndPvList(ctx, logger, r.Client, pvcsToAdd, vg)
+	return utils.HandleErrorMessage(ctx, logger, r.Client, vg, err, addingPVC)
+}
+
+// isQueuedPVCAdmitted runs the checks of addPersistentVolumeClaimToVolumeGroupObjects again when the batch
+// is flushed, the claim, its volume and the other volumeGroups of its namespace may have changed since it
+// was queued. namespaceVGList is read from the API server, it has the claims added by earlier batches.
+func (r *PersistentVolumeClaimReconciler) isQueuedPVCAdmitted(ctx context.Context, logger logr.Logger,
+	pvc *corev1.PersistentVolumeClaim, driver string, namespaceVGList csiv2.VolumeGroupList) (bool, error) {
+	isPVCNeedToBeHandled, err := r.isPVCNeedToBeHandled(ctx, logger, pvc)
+	if err != nil || !isPVCNeedToBeHandled {
+		return false, err
+	}
+	pvcDriver, err := utils.GetPVCDriver(ctx, logger, r.Client, pvc)
+	if err != nil {
+		return false, err
+	}
+	if pvcDriver != driver {
+		return false, nil
+	}
+	if err = utils.ValidatePVCVolumeIsCSI(ctx, logger, r.Client, pvc); err != nil {
+		return false, r.handleNotCSIVolume(ctx, logger, pvc, err)
+	}
+	if r.DriverConfig.MultipleVGsToPVC == "true" {
+		return true, nil
+	}
+	err = utils.IsPVCCanBeAddedToVG(ctx, logger, r.Client, pvc, namespaceVGList.Items)
+	if hErr := utils.HandlePVCErrorMessage(ctx, logger, r.Client, pvc, err, addingPVC); hErr != nil {
+		return false, hErr
+	}
+	return err == nil, nil
+}
+
+// getQueuedPVCs returns the queued persistentVolumeClaims that still exist, sorted by namespace and name.
+// They are read from the API server, the cache may not have the changes made during the batch window.
+func (r *PersistentVolumeClaimReconciler) getQueuedPVCs(ctx context.Context, pvcKeys map[types.NamespacedName]bool) (
+	[]corev1.PersistentVolumeClaim, error) {
+	keys := []types.NamespacedName{}
+	for pvcKey := range pvcKeys {
+		keys = append(keys, pvcKey)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	pvcs := []corev1.PersistentVolumeClaim{}
+	for _, pvcKey := range keys {
+		pvc := corev1.PersistentVolumeClaim{}
+		if err := r.APIReader.Get(ctx, pvcKey, &pvc); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		pvcs = append(pvcs, pvc)
+	}
+	return pvcs, nil
+}
+
+func (r PersistentVolumeClaimReconciler) isPVCCanBeAddedToVG(ctx context.Context, logger logr.Logger, pvc *corev1.PersistentVolumeClaim,
+	vgList csiv2.VolumeGroupList) error {
 	if r.DriverConfig.MultipleVGsToPVC == "true" {
 		return nil
 	}
-	err := utils.IsPVCCanBeAddedToVG(logger, r.Client, pvc, vgList.Items)
-	if hErr := utils.HandlePVCErrorMessage(logger, r.Client, pvc, err, addingPVC); hErr != nil {
+	err := utils.IsPVCCanBeAddedToVG(ctx, logger, r.Client, pvc, vgList.Items)
+	if hErr := utils.HandlePVCErrorMessage(ctx, logger, r.Client, pvc, err, addingPVC); hErr != nil {
 		return hErr
 	}
 	return err
 }
 
 func (r *PersistentVolumeClaimReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.DriverConfig) error {
-	r.VolumeGroupClient = grpcClient.NewVolumeGroupClient(r.GRPCClient.Client, cfg.RPCTimeout)
+	r.VolumeGroupClients = grpcClient.NewVolumeGroupClients(r.GRPCClients, cfg.RPCTimeout)
+	r.membershipBatcher = newMembershipBatcher(r.Log.WithName("MembershipBatcher"), cfg.MembershipBatchWindow,
+		utils.NewDriverRetryRateLimiter(cfg.RetryIntervalStart, cfg.RetryIntervalMax), r.flushMembershipBatch)
+	if err := mgr.Add(r.membershipBatcher); err != nil {
+		return err
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.PersistentVolumeClaim{}, builder.WithPredicates(pvcPredicate)).
+		For(&corev1.PersistentVolumeClaim{}, builder.WithPredicates(utils.PVCMembershipPredicate)).
+		WithOptions(controller.Options{RateLimiter: utils.NewDriverRetryRateLimiter(cfg.RetryIntervalStart, cfg.RetryIntervalMax)}).
 		Complete(r)
 }
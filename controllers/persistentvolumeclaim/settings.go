@@ -16,40 +16,9 @@ limitations under the License.
 
 package persistentvolumeclaim
 
-import (
-	"reflect"
-
-	corev1 "k8s.io/api/core/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/event"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
-)
-
 var (
-	pvcPredicate = predicate.Funcs{
-		CreateFunc: func(e event.CreateEvent) bool {
-			return true
-		},
-		DeleteFunc: func(e event.DeleteEvent) bool {
-			return false
-		},
-		UpdateFunc: func(e event.UpdateEvent) bool {
-			return isLabelsChanged(e.ObjectOld, e.ObjectNew) || isPhaseChanged(e.ObjectOld, e.ObjectNew)
-		},
-		GenericFunc: func(e event.GenericEvent) bool {
-			return false
-		},
-	}
-	removingPVC = "removePVC"
-	addingPVC   = "addPVC"
+	removingPVC                     = "removePVC"
+	modifyingPVCs                   = "modifyPVCs"
+	addingPVC                       = "addPVC"
+	persistentVolumeClaimController = "PersistentVolumeClaim"
 )
-
-func isLabelsChanged(oldObject, newObject client.Object) bool {
-	return !reflect.DeepEqual(oldObject.(*corev1.PersistentVolumeClaim).Labels,
-		newObject.(*corev1.PersistentVolumeClaim).Labels)
-}
-
-func isPhaseChanged(oldObject, newObject client.Object) bool {
-	return !reflect.DeepEqual(oldObject.(*corev1.PersistentVolumeClaim).Status.Phase,
-		newObject.(*corev1.PersistentVolumeClaim).Status.Phase)
-}
@@ -1,8 +1,11 @@
 package controllers
 
+import "time"
+
 var (
 	addingPVC       = "addPVC"
 	removingPVC     = "removePVC"
+	modifyingPVCs   = "modifyPVCs"
 	vgReconcile     = "vgReconcile"
 	deleteVG        = "deletingVG"
 	createVG        = "creatingVG"
@@ -10,4 +13,9 @@ var (
 	updateVGC       = "updatingVGC"
 	updateStatusVG  = "updatingStatusVG"
 	updateStatusVGC = "updatingStatusVGC"
+	invalidParams   = "invalidParameters"
+	deletePVCs      = "deletingPVCs"
+	membershipDrift = "membershipDrift"
+
+	pvcDeletionRequeueInterval = 5 * time.Second
 )
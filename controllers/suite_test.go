@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -61,6 +62,8 @@ var _ = BeforeSuite(func() {
 
 	err = volumegroupv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = volumegroupv2.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
 
 	//+kubebuilder:scaffold:scheme
 
@@ -0,0 +1,157 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type conditionReasons struct {
+	conditionType string
+	failed        string
+	succeeded     string
+}
+
+// reasonToCondition maps the reasons used for events to the condition they affect. Only failures
+// to create or bind the group change Bound, other failures are reported in Reconciled.
+var reasonToCondition = map[string]conditionReasons{
+	addingPVC:         {volumegroupv2.ConditionMembershipSynced, "AddPersistentVolumeClaimFailed", "PersistentVolumeClaimAdded"},
+	removingPVC:       {volumegroupv2.ConditionMembershipSynced, "RemovePersistentVolumeClaimFailed", "PersistentVolumeClaimRemoved"},
+	modifyingPVCs:     {volumegroupv2.ConditionMembershipSynced, "ModifyMembershipFailed", "MembershipModified"},
+	membershipDrift:   {volumegroupv2.ConditionMembershipSynced, "MembershipDrift", "MembershipInSync"},
+	createVG:          {volumegroupv2.ConditionBound, "CreateVolumeGroupFailed", bindingSucceeded},
+	createVGC:         {volumegroupv2.ConditionBound, "CreateVolumeGroupContentFailed", bindingSucceeded},
+	updateVGC:         {volumegroupv2.ConditionBound, "UpdateVolumeGroupContentFailed", bindingSucceeded},
+	updateStatusVG:    {volumegroupv2.ConditionReconciled, "UpdateVolumeGroupStatusFailed", reconcileSucceeded},
+	updateStatusVGC:   {volumegroupv2.ConditionReconciled, "UpdateVolumeGroupContentStatusFailed", reconcileSucceeded},
+	vgReconcile:       {volumegroupv2.ConditionReconciled, "ReconcileFailed", reconcileSucceeded},
+	deleteVG:          {volumegroupv2.ConditionDeletionBlocked, "DeleteVolumeGroupFailed", "Deleting"},
+	deletePVCs:        {volumegroupv2.ConditionDeletionBlocked, "DeletePersistentVolumeClaimsFailed", "Deleting"},
+	invalidParameters: {volumegroupv2.ConditionReconciled, "InvalidParameters", reconcileSucceeded},
+}
+
+func getConditionReasons(reason string) conditionReasons {
+	if reasons, ok := reasonToCondition[reason]; ok {
+		return reasons
+	}
+	return reasonToCondition[vgReconcile]
+}
+
+func setFailedCondition(conditions *[]metav1.Condition, generation int64, err error, reason, message string) {
+	reasons := getConditionReasons(reason)
+	conditionStatus := metav1.ConditionFalse
+	if reasons.conditionType == volumegroupv2.ConditionDeletionBlocked {
+		conditionStatus = metav1.ConditionTrue
+	}
+	setCondition(conditions, generation, reasons.conditionType, conditionStatus, reasons.failed, message)
+	setDriverReachableCondition(conditions, generation, err)
+}
+
+func setSucceededCondition(conditions *[]metav1.Condition, generation int64, reason, message string) {
+	reasons := getConditionReasons(reason)
+	conditionStatus := metav1.ConditionTrue
+	if reasons.conditionType == volumegroupv2.ConditionDeletionBlocked {
+		conditionStatus = metav1.ConditionFalse
+	}
+	setCondition(conditions, generation, reasons.conditionType, conditionStatus, reasons.succeeded, message)
+	setCondition(conditions, generation, volumegroupv2.ConditionDriverReachable, metav1.ConditionTrue,
+		driverResponded, "")
+}
+
+func setBoundCondition(conditions *[]metav1.Condition, generation int64, message string) {
+	setCondition(conditions, generation, volumegroupv2.ConditionBound, metav1.ConditionTrue, bindingSucceeded, message)
+}
+
+func setReconciledCondition(conditions *[]metav1.Condition, generation int64) {
+	setCondition(conditions, generation, volumegroupv2.ConditionReconciled, metav1.ConditionTrue, reconcileSucceeded, "")
+}
+
+func setReleasedCondition(conditions *[]metav1.Condition, generation int64, message string) {
+	setCondition(conditions, generation, volumegroupv2.ConditionBound, metav1.ConditionFalse, bindingReleased, message)
+}
+
+// setDriverReachableCondition updates the DriverReachable condition only for
+// errors that were returned from a gRPC call, other errors say nothing about the driver.
+func setDriverReachableCondition(conditions *[]metav1.Condition, generation int64, err error) {
+	s, ok := status.FromError(err)
+	if err == nil || !ok {
+		return
+	}
+	switch s.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		setCondition(conditions, generation, volumegroupv2.ConditionDriverReachable, metav1.ConditionFalse,
+			"DriverUnreachable", s.Message())
+	default:
+		setCondition(conditions, generation, volumegroupv2.ConditionDriverReachable, metav1.ConditionTrue,
+			driverResponded, "")
+	}
+}
+
+func setCondition(conditions *[]metav1.Condition, generation int64, conditionType string,
+	conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	setReadyCondition(conditions, generation)
+}
+
+func setReadyCondition(conditions *[]metav1.Condition, generation int64) {
+	notReady := findNotReadyCondition(*conditions)
+	if notReady == nil {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               volumegroupv2.ConditionReady,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             "Ready",
+		})
+		return
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               volumegroupv2.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             notReady.Reason,
+		Message:            notReady.Message,
+	})
+}
+
+func findNotReadyCondition(conditions []metav1.Condition) *metav1.Condition {
+	if condition := meta.FindStatusCondition(conditions, volumegroupv2.ConditionBound); condition == nil {
+		return &metav1.Condition{Reason: "NotBound"}
+	} else if condition.Status != metav1.ConditionTrue {
+		return condition
+	}
+	for _, conditionType := range []string{volumegroupv2.ConditionMembershipSynced, volumegroupv2.ConditionDriverReachable} {
+		if condition := meta.FindStatusCondition(conditions, conditionType); condition != nil &&
+			condition.Status == metav1.ConditionFalse {
+			return condition
+		}
+	}
+	if condition := meta.FindStatusCondition(conditions, volumegroupv2.ConditionDeletionBlocked); condition != nil &&
+		condition.Status == metav1.ConditionTrue {
+		return condition
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFailedConditionOfBoundGroup(t *testing.T) {
+	testCases := []struct {
+		reason              string
+		expectedBound       metav1.ConditionStatus
+		expectedReady       metav1.ConditionStatus
+		expectedReconciled  metav1.ConditionStatus
+		reconciledIsPresent bool
+	}{
+		{reason: vgReconcile, expectedBound: metav1.ConditionTrue, expectedReady: metav1.ConditionTrue,
+			expectedReconciled: metav1.ConditionFalse, reconciledIsPresent: true},
+		{reason: updateStatusVG, expectedBound: metav1.ConditionTrue, expectedReady: metav1.ConditionTrue,
+			expectedReconciled: metav1.ConditionFalse, reconciledIsPresent: true},
+		{reason: invalidParameters, expectedBound: metav1.ConditionTrue, expectedReady: metav1.ConditionTrue,
+			expectedReconciled: metav1.ConditionFalse, reconciledIsPresent: true},
+		{reason: "unknownReason", expectedBound: metav1.ConditionTrue, expectedReady: metav1.ConditionTrue,
+			expectedReconciled: metav1.ConditionFalse, reconciledIsPresent: true},
+		{reason: createVG, expectedBound: metav1.ConditionFalse, expectedReady: metav1.ConditionFalse},
+		{reason: updateVGC, expectedBound: metav1.ConditionFalse, expectedReady: metav1.ConditionFalse},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.reason, func(t *testing.T) {
+			conditions := []metav1.Condition{}
+			setBoundCondition(&conditions, 1, "")
+			setFailedCondition(&conditions, 1, errors.New("failed"), tc.reason, "failed")
+
+			assertConditionStatus(t, conditions, volumegroupv2.ConditionBound, tc.expectedBound)
+			assertConditionStatus(t, conditions, volumegroupv2.ConditionReady, tc.expectedReady)
+			if tc.reconciledIsPresent {
+				assertConditionStatus(t, conditions, volumegroupv2.ConditionReconciled, tc.expectedReconciled)
+			}
+
+			setBoundCondition(&conditions, 1, "")
+			setReconciledCondition(&conditions, 1)
+			assertConditionStatus(t, conditions, volumegroupv2.ConditionReconciled, metav1.ConditionTrue)
+			assertConditionStatus(t, conditions, volumegroupv2.ConditionReady, metav1.ConditionTrue)
+		})
+	}
+}
+
+func assertConditionStatus(t *testing.T, conditions []metav1.Condition, conditionType string, expected metav1.ConditionStatus) {
+	t.Helper()
+	condition := meta.FindStatusCondition(conditions, conditionType)
+	if condition == nil {
+		t.Fatalf("condition %s is missing", conditionType)
+	}
+	if condition.Status != expected {
+		t.Errorf("expected condition %s to be %s, got %s (%s)", conditionType, expected, condition.Status, condition.Reason)
+	}
+}
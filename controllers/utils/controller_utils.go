@@ -6,7 +6,7 @@ import (
 	"math/rand"
 	"time"
 
-	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
 	"github.com/go-logr/logr"
@@ -17,15 +17,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func UpdateObject(client client.Client, updateObject client.Object) error {
-	if err := client.Update(context.TODO(), updateObject); err != nil {
+func UpdateObject(ctx context.Context, client client.Client, updateObject client.Object) error {
+	if err := client.Update(ctx, updateObject); err != nil {
 		return fmt.Errorf("failed to update %s (%s/%s) %w", updateObject.GetObjectKind(), updateObject.GetNamespace(), updateObject.GetName(), err)
 	}
 	return nil
 }
 
-func UpdateObjectStatus(client client.Client, updateObject client.Object) error {
-	if err := client.Status().Update(context.TODO(), updateObject); err != nil {
+func UpdateObjectStatus(ctx context.Context, client client.Client, updateObject client.Object) error {
+	if err := client.Status().Update(ctx, updateObject); err != nil {
 		if apierrors.IsConflict(err) {
 			return err
 		}
@@ -34,9 +34,9 @@ func UpdateObjectStatus(client client.Client, updateObject client.Object) error
 	return nil
 }
 
-func getNamespacedObject(client client.Client, obj client.Object) error {
+func getNamespacedObject(ctx context.Context, client client.Client, obj client.Object) error {
 	namespacedObject := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
-	err := client.Get(context.TODO(), namespacedObject, obj)
+	err := client.Get(ctx, namespacedObject, obj)
 	if err != nil {
 		return err
 	}
@@ -63,81 +63,86 @@ func generateString() string {
 	return string(b)
 }
 
-func AddVolumesToVolumeGroup(logger logr.Logger, client client.Client, vgClient grpcClient.VolumeGroup,
-	pvcs []corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
-	logger.Info(fmt.Sprintf(messages.AddVolumeToVolumeGroup, vg.Namespace, vg.Name))
-	vg.Status.PVCList = appendMultiplePVCs(vg.Status.PVCList, pvcs)
+// ModifyVolumeGroupMembership adds and removes the volumes of the persistentVolumeClaims with a
+// single request to the driver.
+func ModifyVolumeGroupMembership(ctx context.Context, logger logr.Logger, client client.Client, vgClient grpcClient.VolumeGroup,
+	pvcsToAdd, pvcsToRemove []corev1.PersistentVolumeClaim, vg *volumegroupv2.VolumeGroup) error {
+	logger.Info(fmt.Sprintf(messages.ModifyVolumeGroupMembership, len(pvcsToAdd), len(pvcsToRemove), vg.Namespace, vg.Name))
+	membersToAdd, err := getVolumeGroupMembers(ctx, logger, client, pvcsToAdd)
+	if err != nil {
+		return err
+	}
+	members := vg.Status.Members
+	vg.Status.Members = appendMultipleMembers(removeMultiplePVCs(copyMembers(members), pvcsToRemove), membersToAdd)
 
-	err := ModifyVolumeGroup(logger, client, vg, vgClient)
+	err = ModifyVolumeGroup(ctx, logger, client, vg, vgClient)
 	if err != nil {
-		vg.Status.PVCList = removeMultiplePVCs(vg.Status.PVCList, pvcs)
+		vg.Status.Members = members
 		return err
 	}
-	logger.Info(fmt.Sprintf(messages.AddedVolumeToVolumeGroup, vg.Namespace, vg.Name))
 	return nil
 }
 
-func AddVolumeToPvcListAndPvList(logger logr.Logger, client client.Client,
-	pvc *corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
-	err := AddPVCToVG(logger, client, pvc, vg)
+// AddVolumesToPvcListAndPvList records persistentVolumeClaims that were added to the group on the storage
+// with one update of the volumeGroup and one of its volumeGroupContent.
+func AddVolumesToPvcListAndPvList(ctx context.Context, logger logr.Logger, client client.Client,
+	pvcs []corev1.PersistentVolumeClaim, vg *volumegroupv2.VolumeGroup) error {
+	if len(pvcs) == 0 {
+		return nil
+	}
+	membersToAdd, err := getVolumeGroupMembers(ctx, logger, client, pvcs)
 	if err != nil {
 		return err
 	}
+	members := appendMultipleMembers(copyMembers(vg.Status.Members), membersToAdd)
+	if err = updateVolumeGroupMembers(ctx, logger, client, vg, members); err != nil {
+		return err
+	}
 
-	err = AddMatchingPVToMatchingVGC(logger, client, pvc, vg)
+	vgc, err := GetVolumeGroupContent(ctx, client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Name, vg.Namespace)
 	if err != nil {
 		return err
 	}
-
-	if err = AddFinalizerToPVC(client, logger, pvc); err != nil {
+	vgcMembers := appendMultipleMembers(copyMembers(vgc.Status.Members), membersToAdd)
+	if err = updateVolumeGroupContentMembers(ctx, logger, client, vgc, vgcMembers); err != nil {
 		return err
 	}
 
-	message := fmt.Sprintf(messages.AddedPersistentVolumeClaimToVolumeGroup, pvc.Namespace, pvc.Name, vg.Namespace, vg.Name)
-	return HandleSuccessMessage(logger, client, vg, message, addingPVC)
-}
-
-func RemoveVolumeFromVolumeGroup(logger logr.Logger, client client.Client, vgClient grpcClient.VolumeGroup,
-	pvcs []corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
-	logger.Info(fmt.Sprintf(messages.RemoveVolumeFromVolumeGroup, vg.Namespace, vg.Name))
-	vg.Status.PVCList = removeMultiplePVCs(vg.Status.PVCList, pvcs)
-
-	err := ModifyVolumeGroup(logger, client, vg, vgClient)
-	if err != nil {
-		vg.Status.PVCList = appendMultiplePVCs(vg.Status.PVCList, pvcs)
-		return err
+	for i := range pvcs {
+		if err = AddFinalizerToPVC(ctx, client, logger, &pvcs[i]); err != nil {
+			return err
+		}
 	}
-	logger.Info(fmt.Sprintf(messages.RemovedVolumeFromVolumeGroup, vg.Namespace, vg.Name))
-	return nil
+
+	message := fmt.Sprintf(messages.AddedPersistentVolumeClaimsToVolumeGroup, len(pvcs), vg.Namespace, vg.Name)
+	return HandleSuccessMessage(ctx, logger, client, vg, message, addingPVC)
 }
 
-func RemoveVolumeFromPvcListAndPvList(logger logr.Logger, client client.Client, driver string,
-	pvc *corev1.PersistentVolumeClaim, vg volumegroupv1.VolumeGroup) error {
-	err := RemovePVCFromVG(logger, client, pvc, &vg)
-	if err != nil {
-		return err
+// RemoveVolumesFromPvcListAndPvList records persistentVolumeClaims that were removed from the group on the
+// storage with one update of the volumeGroup and one of its volumeGroupContent.
+func RemoveVolumesFromPvcListAndPvList(ctx context.Context, logger logr.Logger, client client.Client, driver string,
+	pvcs []corev1.PersistentVolumeClaim, vg *volumegroupv2.VolumeGroup) error {
+	if len(pvcs) == 0 {
+		return nil
 	}
-	pv, err := GetPVFromPVC(logger, client, pvc)
-	if err != nil {
+	members := removeMultiplePVCs(copyMembers(vg.Status.Members), pvcs)
+	if err := updateVolumeGroupMembers(ctx, logger, client, vg, members); err != nil {
 		return err
 	}
-	vgc, err := GetVolumeGroupContent(client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Name, vg.Namespace)
+
+	vgc, err := GetVolumeGroupContent(ctx, client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Name, vg.Namespace)
 	if err != nil {
 		return err
 	}
-
-	if pv != nil {
-		err = RemovePVFromVGC(logger, client, pv, vgc)
-		if err != nil {
-			return err
-		}
+	vgcMembers := removeMultiplePVCs(copyMembers(vgc.Status.Members), pvcs)
+	if err = updateVolumeGroupContentMembers(ctx, logger, client, vgc, vgcMembers); err != nil {
+		return err
 	}
 
-	err = RemoveFinalizerFromPVC(client, logger, driver, pvc)
-	if err != nil {
+	if err = RemoveFinalizerFromPVCs(ctx, client, logger, driver, pvcs); err != nil {
 		return err
 	}
 
-	message := fmt.Sprintf(messages.RemovedPersistentVolumeClaimFromVolumeGroup, pvc.Namespace, pvc.Name, vg.Namespace, vg.Name)
-	return HandleSuccessMessage(logger, client, &vg, message, removingPVC)
+	message := fmt.Sprintf(messages.RemovedPersistentVolumeClaimsFromVolumeGroup, len(pvcs), vg.Namespace, vg.Name)
+	return HandleSuccessMessage(ctx, logger, client, vg, message, removingPVC)
 }
@@ -12,26 +12,26 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func createSuccessNamespacedObjectEvent(logger logr.Logger, client client.Client, object client.Object,
+func createSuccessNamespacedObjectEvent(ctx context.Context, logger logr.Logger, client client.Client, object client.Object,
 	message, reason string) error {
 	event := generateEvent(object, reason, message, normalEventType)
 	logger.Info(fmt.Sprintf(messages.CreateEventForNamespacedObject, object.GetNamespace(), object.GetName(),
 		object.GetObjectKind().GroupVersionKind().Kind, message))
-	return createEvent(logger, client, event)
+	return createEvent(ctx, logger, client, event)
 }
 
-func createNamespacedObjectErrorEvent(logger logr.Logger, client client.Client, object client.Object,
+func createNamespacedObjectErrorEvent(ctx context.Context, logger logr.Logger, client client.Client, object client.Object,
 	errorMessage, reason string) error {
 	event := generateEvent(object, reason, errorMessage, warningEventType)
 	logger.Info(fmt.Sprintf(messages.CreateEventForNamespacedObject, object.GetNamespace(), object.GetName(),
 		object.GetObjectKind().GroupVersionKind().Kind, errorMessage))
-	return createEvent(logger, client, event)
+	return createEvent(ctx, logger, client, event)
 }
 
 func generateEvent(object client.Object, reason, message, eventType string) *corev1.Event {
 	return &corev1.Event{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: object.GetNamespace(),
+			Namespace: getEventNamespace(object),
 			Name:      fmt.Sprintf("%s.%s", object.GetName(), generateString()),
 		},
 		ReportingController: volumeGroupController,
@@ -51,8 +51,8 @@ func generateEvent(object client.Object, reason, message, eventType string) *cor
 	}
 }
 
-func createEvent(logger logr.Logger, client client.Client, event *corev1.Event) error {
-	err := client.Create(context.TODO(), event)
+func createEvent(ctx context.Context, logger logr.Logger, client client.Client, event *corev1.Event) error {
+	err := client.Create(ctx, event)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf(messages.FailedToCreateEvent, event.Namespace, event.Name))
 		return err
@@ -60,3 +60,12 @@ func createEvent(logger logr.Logger, client client.Client, event *corev1.Event)
 	logger.Info(fmt.Sprintf(messages.EventCreated, event.Namespace, event.Name))
 	return nil
 }
+
+// getEventNamespace returns the namespace of the event, events of cluster scoped objects are
+// created in the default namespace.
+func getEventNamespace(object client.Object) string {
+	if object.GetNamespace() == "" {
+		return metav1.NamespaceDefault
+	}
+	return object.GetNamespace()
+}
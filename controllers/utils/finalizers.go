@@ -17,9 +17,10 @@ limitations under the License.
 package utils
 
 import (
+	"context"
 	"fmt"
 
-	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -28,11 +29,11 @@ import (
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func AddFinalizerToVG(client runtimeclient.Client, logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
+func AddFinalizerToVG(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vg *volumegroupv2.VolumeGroup) error {
 	if !Contains(vg.ObjectMeta.Finalizers, VolumeGroupFinalizer) {
 		logger.Info("adding finalizer to VolumeGroup object", "Finalizer", VolumeGroupFinalizer)
 		vg.ObjectMeta.Finalizers = append(vg.ObjectMeta.Finalizers, VolumeGroupFinalizer)
-		if err := updateFinalizer(logger, client, vg.ObjectMeta.Finalizers, vg); err != nil {
+		if err := updateFinalizer(ctx, logger, client, vg.ObjectMeta.Finalizers, vg); err != nil {
 			logger.Error(err, "failed to add finalizer to volumeGroup resource", "finalizer", VolumeGroupFinalizer)
 			return err
 		}
@@ -41,11 +42,11 @@ func AddFinalizerToVG(client runtimeclient.Client, logger logr.Logger, vg *volum
 	return nil
 }
 
-func AddFinalizerToVGC(client runtimeclient.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
+func AddFinalizerToVGC(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vgc *volumegroupv2.VolumeGroupContent) error {
 	if !Contains(vgc.ObjectMeta.Finalizers, volumeGroupContentFinalizer) {
 		logger.Info("adding finalizer to volumeGroupContent object", "Name", vgc.Name, "Finalizer", volumeGroupContentFinalizer)
 		vgc.ObjectMeta.Finalizers = append(vgc.ObjectMeta.Finalizers, volumeGroupContentFinalizer)
-		if err := updateFinalizer(logger, client, vgc.ObjectMeta.Finalizers, vgc); err != nil {
+		if err := updateFinalizer(ctx, logger, client, vgc.ObjectMeta.Finalizers, vgc); err != nil {
 			logger.Error(err, "failed to add finalizer to volumeGroupContent resource", "finalizer", VolumeGroupFinalizer)
 			return err
 		}
@@ -54,11 +55,11 @@ func AddFinalizerToVGC(client runtimeclient.Client, logger logr.Logger, vgc *vol
 	return nil
 }
 
-func RemoveFinalizerFromVG(client runtimeclient.Client, logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
+func RemoveFinalizerFromVG(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vg *volumegroupv2.VolumeGroup) error {
 	if Contains(vg.ObjectMeta.Finalizers, VolumeGroupFinalizer) {
 		logger.Info("removing finalizer from VolumeGroup object", "Finalizer", VolumeGroupFinalizer)
 		vg.ObjectMeta.Finalizers = remove(vg.ObjectMeta.Finalizers, VolumeGroupFinalizer)
-		if err := updateFinalizer(logger, client, vg.ObjectMeta.Finalizers, vg); err != nil {
+		if err := updateFinalizer(ctx, logger, client, vg.ObjectMeta.Finalizers, vg); err != nil {
 			logger.Error(err, "failed to remove finalizer to VolumeGroup resource", "finalizer", VolumeGroupFinalizer)
 			return err
 		}
@@ -67,11 +68,11 @@ func RemoveFinalizerFromVG(client runtimeclient.Client, logger logr.Logger, vg *
 	return nil
 }
 
-func RemoveFinalizerFromVGC(client runtimeclient.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
+func RemoveFinalizerFromVGC(ctx context.Context, client runtimeclient.Client, logger logr.Logger, vgc *volumegroupv2.VolumeGroupContent) error {
 	if Contains(vgc.ObjectMeta.Finalizers, volumeGroupContentFinalizer) {
 		logger.Info("removing finalizer from VolumeGroupContent object", "Name", vgc.Name, "Finalizer", volumeGroupContentFinalizer)
 		vgc.ObjectMeta.Finalizers = remove(vgc.ObjectMeta.Finalizers, volumeGroupContentFinalizer)
-		if err := updateFinalizer(logger, client, vgc.ObjectMeta.Finalizers, vgc); err != nil {
+		if err := updateFinalizer(ctx, logger, client, vgc.ObjectMeta.Finalizers, vgc); err != nil {
 			logger.Error(err, "failed to remove finalizer to VolumeGroupContent resource", "finalizer", VolumeGroupFinalizer)
 			return err
 		}
@@ -80,11 +81,11 @@ func RemoveFinalizerFromVGC(client runtimeclient.Client, logger logr.Logger, vgc
 	return nil
 }
 
-func AddFinalizerToPVC(client runtimeclient.Client, logger logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
+func AddFinalizerToPVC(ctx context.Context, client runtimeclient.Client, logger logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
 	if !Contains(pvc.ObjectMeta.Finalizers, pvcVolumeGroupFinalizer) {
 		logger.Info("adding finalizer to PersistentVolumeClaim object", "Namespace", pvc.Namespace, "Name", pvc.Name, "Finalizer", pvcVolumeGroupFinalizer)
 		pvc.ObjectMeta.Finalizers = append(pvc.ObjectMeta.Finalizers, pvcVolumeGroupFinalizer)
-		if err := updateFinalizer(logger, client, pvc.ObjectMeta.Finalizers, pvc); err != nil {
+		if err := updateFinalizer(ctx, logger, client, pvc.ObjectMeta.Finalizers, pvc); err != nil {
 			logger.Error(err, "failed to add finalizer to PersistentVolumeClaim resource", "finalizer", VolumeGroupFinalizer)
 			return err
 		}
@@ -93,52 +94,60 @@ func AddFinalizerToPVC(client runtimeclient.Client, logger logr.Logger, pvc *cor
 	return nil
 }
 
-func RemoveFinalizerFromPVC(client runtimeclient.Client, logger logr.Logger, driver string,
-	pvc *corev1.PersistentVolumeClaim) error {
-	removeFinalizer, err := isFinalizerShouldBeREmovedFromPVC(logger, client, driver, pvc)
+// RemoveFinalizerFromPVCs removes the finalizer from the persistentVolumeClaims that are no longer part
+// of any volumeGroup of the driver, the volumeGroups are listed once for all of them.
+func RemoveFinalizerFromPVCs(ctx context.Context, client runtimeclient.Client, logger logr.Logger, driver string,
+	pvcs []corev1.PersistentVolumeClaim) error {
+	vgList, err := GetVGList(ctx, logger, client, driver)
 	if err != nil {
 		return err
 	}
-
-	if removeFinalizer {
+	for i := range pvcs {
+		pvc := &pvcs[i]
+		if IsPVCPartAnyVG(pvc, vgList.Items) || !Contains(pvc.ObjectMeta.Finalizers, pvcVolumeGroupFinalizer) {
+			continue
+		}
 		logger.Info("removing finalizer from PersistentVolumeClaim object", "Namespace", pvc.Namespace, "Name", pvc.Name, "Finalizer", pvcVolumeGroupFinalizer)
-		uErr := getNamespacedObject(client, pvc)
-		if uErr != nil {
-			return uErr
+		if err = getNamespacedObject(ctx, client, pvc); err != nil {
+			return err
 		}
 		pvc.ObjectMeta.Finalizers = remove(pvc.ObjectMeta.Finalizers, pvcVolumeGroupFinalizer)
-		if err := updateFinalizer(logger, client, pvc.ObjectMeta.Finalizers, pvc); err != nil {
+		if err = updateFinalizer(ctx, logger, client, pvc.ObjectMeta.Finalizers, pvc); err != nil {
 			logger.Error(err, "failed to remove finalizer to PersistentVolumeClaim resource", "finalizer", VolumeGroupFinalizer)
 			return err
 		}
 	}
-
 	return nil
 }
 
-func isFinalizerShouldBeREmovedFromPVC(logger logr.Logger, client runtimeclient.Client, driver string,
-	pvc *corev1.PersistentVolumeClaim) (bool, error) {
-	vgList, err := GetVGList(logger, client, driver)
-	if err != nil {
-		return false, err
+func removeVolumeGroupFinalizerFromPVC(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
+	pvc *corev1.PersistentVolumeClaim) error {
+	if !Contains(pvc.ObjectMeta.Finalizers, pvcVolumeGroupFinalizer) {
+		return nil
 	}
-	return !IsPVCPartAnyVG(pvc, vgList.Items) && Contains(pvc.ObjectMeta.Finalizers, pvcVolumeGroupFinalizer), nil
+	logger.Info("removing finalizer from PersistentVolumeClaim object", "Namespace", pvc.Namespace, "Name", pvc.Name, "Finalizer", pvcVolumeGroupFinalizer)
+	pvc.ObjectMeta.Finalizers = remove(pvc.ObjectMeta.Finalizers, pvcVolumeGroupFinalizer)
+	if err := updateFinalizer(ctx, logger, client, pvc.ObjectMeta.Finalizers, pvc); err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "failed to remove finalizer to PersistentVolumeClaim resource", "finalizer", pvcVolumeGroupFinalizer)
+		return err
+	}
+	return nil
 }
 
-func updateFinalizer(logger logr.Logger, client runtimeclient.Client,
+func updateFinalizer(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
 	finalizers []string, obj runtimeclient.Object) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		return finalizerRetryOnConflictFunc(logger, client, finalizers, obj)
+		return finalizerRetryOnConflictFunc(ctx, logger, client, finalizers, obj)
 	})
 	return err
 }
 
-func finalizerRetryOnConflictFunc(logger logr.Logger, client runtimeclient.Client,
+func finalizerRetryOnConflictFunc(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
 	finalizers []string, obj runtimeclient.Object) error {
 	obj.SetFinalizers(finalizers)
-	err := UpdateObject(client, obj)
+	err := UpdateObject(ctx, client, obj)
 	if apierrors.IsConflict(err) {
-		uErr := getNamespacedObject(client, obj)
+		uErr := getNamespacedObject(ctx, client, obj)
 		if uErr != nil {
 			return uErr
 		}
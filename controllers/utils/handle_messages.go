@@ -1,21 +1,23 @@
 package utils
 
 import (
-	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"context"
+
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func HandleErrorMessage(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+func HandleErrorMessage(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv2.VolumeGroup,
 	err error, reason string) error {
 	if err != nil {
 		errorMessage := GetMessageFromError(err)
-		uErr := UpdateVolumeGroupStatusError(client, vg, logger, errorMessage)
+		uErr := UpdateVolumeGroupStatusCondition(ctx, client, vg, logger, err, reason)
 		if uErr != nil {
 			return uErr
 		}
-		uErr = createNamespacedObjectErrorEvent(logger, client, vg, errorMessage, reason)
+		uErr = createNamespacedObjectErrorEvent(ctx, logger, client, vg, errorMessage, reason)
 		if uErr != nil {
 			return uErr
 		}
@@ -24,34 +26,37 @@ func HandleErrorMessage(logger logr.Logger, client client.Client, vg *volumegrou
 	return nil
 }
 
-func HandleSuccessMessage(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup, message, reason string) error {
-	err := UpdateVolumeGroupStatusError(client, vg, logger, "")
+func HandleSuccessMessage(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv2.VolumeGroup, message, reason string) error {
+	err := updateVolumeGroupStatusSucceededCondition(ctx, client, vg, logger, message, reason)
 	if err != nil {
 		return err
 	}
-	err = createSuccessNamespacedObjectEvent(logger, client, vg, message, reason)
+	err = createSuccessNamespacedObjectEvent(ctx, logger, client, vg, message, reason)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func HandlePVCErrorMessage(logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim,
+func HandlePVCErrorMessage(ctx context.Context, logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim,
 	err error, reason string) error {
 	if err != nil {
 		errorMessage := GetMessageFromError(err)
-		if uErr := createNamespacedObjectErrorEvent(logger, client, pvc, errorMessage, reason); uErr != nil {
+		if uErr := createNamespacedObjectErrorEvent(ctx, logger, client, pvc, errorMessage, reason); uErr != nil {
 			return uErr
 		}
 	}
 	return nil
 }
 
-func HandleVGCErrorMessage(logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent,
+func HandleVGCErrorMessage(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv2.VolumeGroupContent,
 	err error, reason string) error {
 	if err != nil {
 		errorMessage := GetMessageFromError(err)
-		if uErr := createNamespacedObjectErrorEvent(logger, client, vgc, errorMessage, reason); uErr != nil {
+		if uErr := UpdateVolumeGroupContentStatusCondition(ctx, client, vgc, logger, err, reason); uErr != nil {
+			return uErr
+		}
+		if uErr := createNamespacedObjectErrorEvent(ctx, logger, client, vgc, errorMessage, reason); uErr != nil {
 			return uErr
 		}
 	}
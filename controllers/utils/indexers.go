@@ -0,0 +1,94 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The cache indexes below let the controllers list only the volumeGroups of a driver or of a
+// persistentVolumeClaim. The driver of a volumeGroup is found through its class, volumeGroupClasses
+// are few and their driver never changes. The cache also keys every field index by namespace, so a
+// list in a namespace only reads the volumeGroups of that namespace.
+const (
+	vgClassField   = "spec.volumeGroupClassName"
+	vgPVCField     = "status.members"
+	vgPVCNameField = "spec.source.persistentVolumeClaimNames"
+)
+
+// SetupIndexers adds the field indexes used to list volumeGroups to the cache, it must be called
+// before the manager starts.
+func SetupIndexers(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &volumegroupv2.VolumeGroup{}, vgClassField, indexVGClass); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &volumegroupv2.VolumeGroup{}, vgPVCField, indexVGPVCs); err != nil {
+		return err
+	}
+	return indexer.IndexField(ctx, &volumegroupv2.VolumeGroup{}, vgPVCNameField, indexVGPVCNames)
+}
+
+func indexVGClass(object client.Object) []string {
+	vg := object.(*volumegroupv2.VolumeGroup)
+	if vg.Spec.VolumeGroupClassName == nil {
+		return nil
+	}
+	return []string{*vg.Spec.VolumeGroupClassName}
+}
+
+func indexVGPVCs(object client.Object) []string {
+	vg := object.(*volumegroupv2.VolumeGroup)
+	pvcKeys := make([]string, 0, len(vg.Status.Members))
+	for _, member := range vg.Status.Members {
+		pvcKeys = append(pvcKeys, types.NamespacedName{Name: member.Name, Namespace: member.Namespace}.String())
+	}
+	return pvcKeys
+}
+
+func indexVGPVCNames(object client.Object) []string {
+	vg := object.(*volumegroupv2.VolumeGroup)
+	pvcKeys := make([]string, 0, len(vg.Spec.Source.PersistentVolumeClaimNames))
+	for _, pvcName := range vg.Spec.Source.PersistentVolumeClaimNames {
+		pvcKeys = append(pvcKeys, types.NamespacedName{Name: pvcName, Namespace: vg.Namespace}.String())
+	}
+	return pvcKeys
+}
+
+func getPVCIndexKey(pvc *corev1.PersistentVolumeClaim) string {
+	return client.ObjectKeyFromObject(pvc).String()
+}
+
+func matchingVolumeGroupClass(vgClassName string) client.MatchingFields {
+	return client.MatchingFields{vgClassField: vgClassName}
+}
+
+func matchingVolumeGroupClassInNamespace(vgClassName, namespace string) []client.ListOption {
+	return []client.ListOption{matchingVolumeGroupClass(vgClassName), client.InNamespace(namespace)}
+}
+
+func matchingMemberPVC(pvc *corev1.PersistentVolumeClaim) client.MatchingFields {
+	return client.MatchingFields{vgPVCField: getPVCIndexKey(pvc)}
+}
+
+func matchingPVCName(pvc *corev1.PersistentVolumeClaim) client.MatchingFields {
+	return client.MatchingFields{vgPVCNameField: getPVCIndexKey(pvc)}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CheckVolumeGroupMembershipDrift compares the volumes of the group on the storage with the
+// volumes of the persistentVolumeClaims in the volumeGroup status. It returns a
+// VolumeGroupMembershipDriftError when they differ, and nil when the driver cannot get the group.
+func CheckVolumeGroupMembershipDrift(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv2.VolumeGroup,
+	vgClient grpcClient.VolumeGroup) error {
+	params, err := generateModifyVolumeGroupParams(ctx, logger, client, vg, vgClient)
+	if err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf(messages.GetVolumeGroup, params.VolumeGroupID))
+	getVolumeGroupResponse := volumegroup.NewVolumeGroupRequest(params).Get(ctx)
+	if getVolumeGroupResponse.HasKnownGRPCError([]codes.Code{codes.Unimplemented}) {
+		logger.Info(messages.VolumeGroupGetIsNotSupported)
+		return nil
+	}
+	if getVolumeGroupResponse.Error != nil {
+		logger.Error(getVolumeGroupResponse.Error, fmt.Sprintf(messages.FailedToGetVolumeGroup, params.VolumeGroupID))
+		return getVolumeGroupResponse.Error
+	}
+
+	storageVolumeIds := getVolumeIdsFromGetResponse(getVolumeGroupResponse)
+	missingVolumeIds := subtractVolumeIds(params.VolumeIds, storageVolumeIds)
+	unexpectedVolumeIds := subtractVolumeIds(storageVolumeIds, params.VolumeIds)
+	if len(missingVolumeIds) == 0 && len(unexpectedVolumeIds) == 0 {
+		return nil
+	}
+	return &vgerrors.VolumeGroupMembershipDriftError{
+		VolumeGroupID:       params.VolumeGroupID,
+		MissingVolumeIds:    missingVolumeIds,
+		UnexpectedVolumeIds: unexpectedVolumeIds,
+	}
+}
+
+func getVolumeIdsFromGetResponse(resp *volumegroup.Response) []string {
+	volumeIds := []string{}
+	getVolumeGroupResponse := resp.Response.(*csi.ControllerGetVolumeGroupResponse)
+	for _, volume := range getVolumeGroupResponse.GetVolumeGroup().GetVolumes() {
+		volumeIds = append(volumeIds, volume.GetVolumeId())
+	}
+	return volumeIds
+}
+
+func subtractVolumeIds(volumeIds, volumeIdsToSubtract []string) []string {
+	result := []string{}
+	for _, volumeId := range volumeIds {
+		if !Contains(volumeIdsToSubtract, volumeId) {
+			result = append(result, volumeId)
+		}
+	}
+	return result
+}
+
+func IsMembershipDriftRepairEnabled(vgClass *volumegroupv1.VolumeGroupClass) bool {
+	return vgClass.RepairMembershipDrift != nil && *vgClass.RepairMembershipDrift
+}
+
+// UpdateVolumeGroupMembershipInSync marks the membership as synced, events are only created
+// when the membership was not synced before.
+func UpdateVolumeGroupMembershipInSync(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv2.VolumeGroup,
+	message string) error {
+	condition := meta.FindStatusCondition(vg.Status.Conditions, volumegroupv2.ConditionMembershipSynced)
+	if condition != nil && condition.Status == metav1.ConditionTrue {
+		return nil
+	}
+	return HandleSuccessMessage(ctx, logger, client, vg, message, membershipDrift)
+}
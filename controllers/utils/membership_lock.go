@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"context"
+	"sync"
+
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// volumeGroupLocks serializes the membership changes of every volumeGroup, the volumeGroup and the
+// persistentVolumeClaim controllers both modify the group from their own workqueues.
+var volumeGroupLocks = &keyedMutex{locks: map[types.NamespacedName]*keyedMutexEntry{}}
+
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[types.NamespacedName]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	mu      sync.Mutex
+	holders int
+}
+
+func (m *keyedMutex) lock(key types.NamespacedName) func() {
+	m.mu.Lock()
+	entry, ok := m.locks[key]
+	if !ok {
+		entry = &keyedMutexEntry{}
+		m.locks[key] = entry
+	}
+	entry.holders++
+	m.mu.Unlock()
+
+	entry.mu.Lock()
+	return func() {
+		entry.mu.Unlock()
+		m.mu.Lock()
+		entry.holders--
+		if entry.holders == 0 {
+			delete(m.locks, key)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// LockVolumeGroupMembership locks the membership of the volumeGroup until the returned function is
+// called. The volumeGroup should be read again with RefreshVolumeGroup once the lock is held.
+func LockVolumeGroupMembership(vg *volumegroupv2.VolumeGroup) func() {
+	return volumeGroupLocks.lock(types.NamespacedName{Name: vg.Name, Namespace: vg.Namespace})
+}
+
+// RefreshVolumeGroup reads the volumeGroup from the API server, the cache may not have the
+// membership that the other controller has just written yet.
+func RefreshVolumeGroup(ctx context.Context, reader client.Reader, vg *volumegroupv2.VolumeGroup) error {
+	return reader.Get(ctx, types.NamespacedName{Name: vg.Name, Namespace: vg.Namespace}, vg)
+}
@@ -1,25 +1,27 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 
-	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
 	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func ModifyVolumeGroup(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup,
+func ModifyVolumeGroup(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv2.VolumeGroup,
 	vgClient grpcClient.VolumeGroup) error {
-	params, err := generateModifyVolumeGroupParams(logger, client, vg, vgClient)
+	params, err := generateModifyVolumeGroupParams(ctx, logger, client, vg, vgClient)
 	if err != nil {
 		return err
 	}
 	logger.Info(fmt.Sprintf(messages.ModifyVolumeGroup, params.VolumeGroupID, params.VolumeIds))
 	volumeGroupRequest := volumegroup.NewVolumeGroupRequest(params)
-	modifyVolumeGroupResponse := volumeGroupRequest.Modify()
+	modifyVolumeGroupResponse := volumeGroupRequest.Modify(ctx)
 	responseError := modifyVolumeGroupResponse.Error
 	if responseError != nil {
 		logger.Error(responseError, fmt.Sprintf(messages.FailedToModifyVolumeGroup, vg.Namespace, vg.Name))
@@ -28,17 +30,67 @@ func ModifyVolumeGroup(logger logr.Logger, client client.Client, vg *volumegroup
 	logger.Info(fmt.Sprintf(messages.ModifiedVolumeGroup, params.VolumeGroupID))
 	return nil
 }
-func generateModifyVolumeGroupParams(logger logr.Logger, client client.Client,
-	vg *volumegroupv1.VolumeGroup, vgClient grpcClient.VolumeGroup) (volumegroup.CommonRequestParameters, error) {
-	vgId, err := getVgId(logger, client, vg)
+
+// AddVolumesToNewVolumeGroup adds the initial members to a volume group that was just created,
+// before its volumeGroupContent exists, so the group is never bound without them.
+func AddVolumesToNewVolumeGroup(ctx context.Context, logger logr.Logger, client client.Client, vgClient grpcClient.VolumeGroup,
+	volumeGroupId string, pvcs []corev1.PersistentVolumeClaim, secrets map[string]string) error {
+	if len(pvcs) == 0 {
+		return nil
+	}
+	volumeIds, err := GetPVCListVolumeIds(ctx, logger, client, pvcs)
+	if err != nil {
+		return err
+	}
+	params := volumegroup.CommonRequestParameters{
+		Secrets:       secrets,
+		VolumeGroup:   vgClient,
+		VolumeGroupID: volumeGroupId,
+		VolumeIds:     volumeIds,
+	}
+	logger.Info(fmt.Sprintf(messages.ModifyVolumeGroup, params.VolumeGroupID, params.VolumeIds))
+	modifyVolumeGroupResponse := volumegroup.NewVolumeGroupRequest(params).Modify(ctx)
+	if modifyVolumeGroupResponse.Error != nil {
+		logger.Error(modifyVolumeGroupResponse.Error, fmt.Sprintf(messages.FailedToAddInitialVolumesToVolumeGroup, volumeGroupId))
+		return modifyVolumeGroupResponse.Error
+	}
+	logger.Info(fmt.Sprintf(messages.ModifiedVolumeGroup, params.VolumeGroupID))
+	return nil
+}
+
+// RemoveVolumesFromDeletedVolumeGroup removes all the volumes from the group on the storage before the
+// persistentVolumeClaims of a deleted volumeGroup are deleted, drivers are not required to delete a
+// volume that is still a member of a group. Emptying a group that is already empty has no effect, so
+// it is sent again until the claims are gone.
+func RemoveVolumesFromDeletedVolumeGroup(ctx context.Context, logger logr.Logger, vgClient grpcClient.VolumeGroup,
+	volumeGroupId string, secrets map[string]string) error {
+	params := volumegroup.CommonRequestParameters{
+		Secrets:       secrets,
+		VolumeGroup:   vgClient,
+		VolumeGroupID: volumeGroupId,
+		VolumeIds:     []string{},
+	}
+	logger.Info(fmt.Sprintf(messages.ModifyVolumeGroup, params.VolumeGroupID, params.VolumeIds))
+	modifyVolumeGroupResponse := volumegroup.NewVolumeGroupRequest(params).Modify(ctx)
+	if modifyVolumeGroupResponse.Error != nil {
+		logger.Error(modifyVolumeGroupResponse.Error, fmt.Sprintf(messages.FailedToRemoveVolumesFromDeletedVolumeGroup, volumeGroupId))
+		return modifyVolumeGroupResponse.Error
+	}
+	logger.Info(fmt.Sprintf(messages.ModifiedVolumeGroup, params.VolumeGroupID))
+	return nil
+}
+
+func generateModifyVolumeGroupParams(ctx context.Context, logger logr.Logger, client client.Client,
+	vg *volumegroupv2.VolumeGroup, vgClient grpcClient.VolumeGroup) (volumegroup.CommonRequestParameters, error) {
+	vgId, err := getVgId(ctx, logger, client, vg)
 	if err != nil {
 		return volumegroup.CommonRequestParameters{}, err
 	}
-	volumeIds, err := getPVCListVolumeIds(logger, client, vg.Status.PVCList)
+	volumeIds, err := GetMembersVolumeIds(ctx, logger, client, vg.Status.Members)
 	if err != nil {
 		return volumegroup.CommonRequestParameters{}, err
 	}
-	secrets, err := getSecrets(logger, client, vg)
+	secrets, err := getSecrets(ctx, logger, client, vg)
 	if err != nil {
 		return volumegroup.CommonRequestParameters{}, err
 	}
@@ -50,12 +102,12 @@ func generateModifyVolumeGroupParams(logger logr.Logger, client client.Client,
 		VolumeIds:     volumeIds,
 	}, nil
 }
-func getSecrets(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup) (map[string]string, error) {
-	vgc, err := GetVolumeGroupClass(client, logger, *vg.Spec.VolumeGroupClassName)
+func getSecrets(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv2.VolumeGroup) (map[string]string, error) {
+	vgc, err := GetVolumeGroupClass(ctx, client, logger, *vg.Spec.VolumeGroupClassName)
 	if err != nil {
 		return nil, err
 	}
-	secrets, err := GetSecretDataFromClass(client, vgc, logger, vg)
+	secrets, err := GetSecretDataFromClass(ctx, client, vgc, logger, vg)
 	if err != nil {
 		return nil, err
 	}
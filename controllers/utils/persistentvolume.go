@@ -13,9 +13,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func GetPVFromPVC(logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolume, error) {
+func GetPVFromPVC(ctx context.Context, logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolume, error) {
 	logger.Info(fmt.Sprintf(messages.GetPersistentVolumeOfPersistentVolumeClaim, pvc.Namespace, pvc.Name))
-	pvName, err := getPersistentVolumeName(logger, client, pvc)
+	pvName, err := getPersistentVolumeName(ctx, logger, client, pvc)
 	if err != nil {
 		return nil, err
 	}
@@ -23,17 +23,46 @@ func GetPVFromPVC(logger logr.Logger, client client.Client, pvc *corev1.Persiste
 		return nil, nil
 	}
 
-	pv, err := getPersistentVolume(logger, client, pvName)
+	pv, err := getPersistentVolume(ctx, logger, client, pvName)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return nil, &vgerrors.PersistentVolumeDoesNotExist{pvName, pvc.Namespace, err.Error()}
+			return nil, &vgerrors.PersistentVolumeDoesNotExist{PVName: pvName, PVNamespace: pvc.Namespace, ErrorMessage: err.Error()}
 		}
 		return nil, err
 	}
 	return pv, nil
 }
 
-func getPersistentVolumeName(logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim) (string, error) {
+// getPersistentVolumeDriver returns the CSI driver of the persistentVolume, in-tree volumes return the
+// driver they were migrated to or an empty string.
+func getPersistentVolumeDriver(pv *corev1.PersistentVolume) string {
+	if pv.Spec.CSI != nil {
+		return pv.Spec.CSI.Driver
+	}
+	return pv.Annotations[pvMigratedToAnnotation]
+}
+
+// getVolumeHandle returns the CSI volume ID of the persistentVolume, in-tree volumes have none even
+// when they are migrated to a CSI driver.
+func getVolumeHandle(pv *corev1.PersistentVolume) (string, error) {
+	if pv.Spec.CSI == nil {
+		return "", &vgerrors.PersistentVolumeIsNotCSIError{PVName: pv.Name, MigratedToDriver: pv.Annotations[pvMigratedToAnnotation]}
+	}
+	return pv.Spec.CSI.VolumeHandle, nil
+}
+
+// ValidatePVCVolumeIsCSI returns PersistentVolumeIsNotCSIError when the persistentVolumeClaim is bound
+// to a volume that cannot be added to a group.
+func ValidatePVCVolumeIsCSI(ctx context.Context, logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim) error {
+	pv, err := GetPVFromPVC(ctx, logger, client, pvc)
+	if err != nil || pv == nil {
+		return err
+	}
+	_, err = getVolumeHandle(pv)
+	return err
+}
+
+func getPersistentVolumeName(ctx context.Context, logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim) (string, error) {
 	pvName := pvc.Spec.VolumeName
 	if pvName == "" {
 		logger.Info(messages.PersistentVolumeClaimDoesNotHavePersistentVolume)
@@ -41,11 +70,11 @@ func getPersistentVolumeName(logger logr.Logger, client client.Client, pvc *core
 	return pvName, nil
 }
 
-func getPersistentVolume(logger logr.Logger, client client.Client, pvName string) (*corev1.PersistentVolume, error) {
+func getPersistentVolume(ctx context.Context, logger logr.Logger, client client.Client, pvName string) (*corev1.PersistentVolume, error) {
 	logger.Info(fmt.Sprintf(messages.GetPersistentVolume, pvName))
 	pv := &corev1.PersistentVolume{}
 	namespacedPV := types.NamespacedName{Name: pvName}
-	err := client.Get(context.TODO(), namespacedPV, pv)
+	err := client.Get(ctx, namespacedPV, pv)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf(messages.FailedToGetPersistentVolume, pvName))
 		return nil, err
@@ -4,34 +4,68 @@ import (
 	"context"
 	"fmt"
 
-	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"github.com/IBM/csi-volume-group-operator/pkg/errors"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func getPVCListVolumeIds(logger logr.Logger, client runtimeclient.Client, pvcList []corev1.PersistentVolumeClaim) ([]string, error) {
+func GetPVCListVolumeIds(ctx context.Context, logger logr.Logger, client runtimeclient.Client, pvcList []corev1.PersistentVolumeClaim) ([]string, error) {
 	volumeIds := []string{}
 	for _, pvc := range pvcList {
-		pv, err := GetPVFromPVC(logger, client, &pvc)
+		pv, err := GetPVFromPVC(ctx, logger, client, &pvc)
 		if err != nil {
 			return nil, err
 		}
-		if pv != nil {
-			volumeIds = append(volumeIds, string(pv.Spec.CSI.VolumeHandle))
+		if pv == nil {
+			continue
 		}
+		volumeHandle, err := getVolumeHandle(pv)
+		if err != nil {
+			return nil, err
+		}
+		volumeIds = append(volumeIds, volumeHandle)
 	}
 	return volumeIds, nil
 }
 
-func GetPersistentVolumeClaim(logger logr.Logger, client runtimeclient.Client, name, namespace string) (*corev1.PersistentVolumeClaim, error) {
+// GetMembersVolumeIds returns the volume IDs of the members of a group, members that were converted from
+// an older version of the API have no volume handle and it is read from their persistentVolume.
+func GetMembersVolumeIds(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
+	members []volumegroupv2.VolumeGroupMember) ([]string, error) {
+	volumeIds := []string{}
+	for _, member := range members {
+		if member.VolumeHandle != "" {
+			volumeIds = append(volumeIds, member.VolumeHandle)
+			continue
+		}
+		if member.PersistentVolumeName == "" {
+			continue
+		}
+		pv, err := getPersistentVolume(ctx, logger, client, member.PersistentVolumeName)
+		if err != nil {
+			return nil, err
+		}
+		volumeHandle, err := getVolumeHandle(pv)
+		if err != nil {
+			return nil, err
+		}
+		volumeIds = append(volumeIds, volumeHandle)
+	}
+	return volumeIds, nil
+}
+
+func GetPersistentVolumeClaim(ctx context.Context, logger logr.Logger, client runtimeclient.Client, name, namespace string) (*corev1.PersistentVolumeClaim, error) {
 	logger.Info(fmt.Sprintf(messages.GetPersistentVolumeClaim, namespace, name))
 	pvc := &corev1.PersistentVolumeClaim{}
 	namespacedPVC := types.NamespacedName{Name: name, Namespace: namespace}
-	err := client.Get(context.TODO(), namespacedPVC, pvc)
+	err := client.Get(ctx, namespacedPVC, pvc)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error(err, fmt.Sprintf(messages.PersistentVolumeClaimNotFound, namespace, name))
@@ -43,104 +77,240 @@ func GetPersistentVolumeClaim(logger logr.Logger, client runtimeclient.Client, n
 	return pvc, nil
 }
 
-func IsPVCCanBeAddedToVG(logger logr.Logger, client runtimeclient.Client,
-	pvc *corev1.PersistentVolumeClaim, vgs []volumegroupv1.VolumeGroup) error {
+func IsPVCCanBeAddedToVG(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
+	pvc *corev1.PersistentVolumeClaim, vgs []volumegroupv2.VolumeGroup) error {
 	vgsWithPVC := []string{}
 	newVGsForPVC := []string{}
 	for _, vg := range vgs {
-		if IsPVCPartOfVG(pvc, vg.Status.PVCList) {
+		if IsPVCPartOfVG(pvc, vg.Status.Members) {
 			vgsWithPVC = append(vgsWithPVC, vg.Name)
-		} else if isPVCMatchesVG, _ := IsPVCMatchesVG(logger, client, pvc, vg); isPVCMatchesVG {
+		} else if isPVCMatchesVG, _ := IsPVCMatchesVG(ctx, logger, client, pvc, vg); isPVCMatchesVG {
 			newVGsForPVC = append(newVGsForPVC, vg.Name)
 		}
 	}
 	return checkIfPVCCanBeAddedToVG(logger, pvc, vgsWithPVC, newVGsForPVC)
 }
 
+// GetLatestVGListOfNamespace returns the volumeGroups of the driver in the namespace, read with reader.
+// It is used with a reader of the API server when the membership written moments ago matters.
+func GetLatestVGListOfNamespace(ctx context.Context, logger logr.Logger, reader runtimeclient.Reader,
+	client runtimeclient.Client, driver, namespace string) (volumegroupv2.VolumeGroupList, error) {
+	logger.Info(messages.ListVolumeGroups)
+	vgClassNames, err := getVolumeGroupClassNamesOfDriver(ctx, client, logger, driver)
+	if err != nil {
+		return volumegroupv2.VolumeGroupList{}, err
+	}
+	namespaceVGList := &volumegroupv2.VolumeGroupList{}
+	if err = reader.List(ctx, namespaceVGList, runtimeclient.InNamespace(namespace)); err != nil {
+		logger.Error(err, messages.FailedToListVolumeGroups)
+		return volumegroupv2.VolumeGroupList{}, err
+	}
+	vgList := volumegroupv2.VolumeGroupList{}
+	for _, vg := range namespaceVGList.Items {
+		if vg.Spec.VolumeGroupClassName != nil && Contains(vgClassNames, *vg.Spec.VolumeGroupClassName) {
+			vgList.Items = append(vgList.Items, vg)
+		}
+	}
+	return vgList, nil
+}
+
 func checkIfPVCCanBeAddedToVG(logger logr.Logger, pvc *corev1.PersistentVolumeClaim,
 	vgsWithPVC, newVGsForPVC []string) error {
 	if len(vgsWithPVC) > 0 && len(newVGsForPVC) > 0 {
 		message := fmt.Sprintf(messages.PersistentVolumeClaimIsAlreadyBelongToGroup, pvc.Namespace, pvc.Name, newVGsForPVC, vgsWithPVC)
 		logger.Info(message)
+		metrics.IncMembershipConflicts(metrics.ConflictAlreadyInGroup)
 		return fmt.Errorf(message)
 	}
 	if len(newVGsForPVC) > 1 {
 		message := fmt.Sprintf(messages.PersistentVolumeClaimMatchedWithMultipleNewGroups, pvc.Namespace, pvc.Name, newVGsForPVC)
 		logger.Info(message)
+		metrics.IncMembershipConflicts(metrics.ConflictMultipleNewGroups)
 		return fmt.Errorf(message)
 	}
 	return nil
 }
 
-func IsPVCInStaticVG(logger logr.Logger, client runtimeclient.Client, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+// IsPVCInStaticVG checks if the storageClass of the persistentVolumeClaim puts its volumes in a group,
+// statically bound claims may have no storageClass or one that was deleted.
+func IsPVCInStaticVG(ctx context.Context, logger logr.Logger, client runtimeclient.Client, pvc *corev1.PersistentVolumeClaim) (bool, error) {
 	storageClassName, sErr := GetPersistentVolumeClaimClass(pvc)
-	if sErr != nil {
-		return false, sErr
+	if sErr != nil || storageClassName == "" {
+		return false, nil
 	}
-	sc, err := getStorageClass(logger, client, storageClassName)
+	sc, err := getStorageClass(ctx, logger, client, storageClassName)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
 		return false, err
 	}
 	return isSCHasParam(sc, storageClassVGParameter), nil
 }
 
-func GetPVCList(logger logr.Logger, client runtimeclient.Client, driver string) (corev1.PersistentVolumeClaimList, error) {
-	pvcList, err := getPVCList(logger, client)
+// GetMatchingPVCList returns the bound persistentVolumeClaims of the driver that the source of the
+// volumeGroup selects. Named claims are read one by one and selected claims are listed by their labels.
+func GetMatchingPVCList(ctx context.Context, logger logr.Logger, client runtimeclient.Client, driver string,
+	vg *volumegroupv2.VolumeGroup) (corev1.PersistentVolumeClaimList, error) {
+	if len(vg.Spec.Source.PersistentVolumeClaimNames) > 0 {
+		return getNamedPVCList(ctx, logger, client, driver, vg.Namespace, vg.Spec.Source.PersistentVolumeClaimNames)
+	}
+	if vg.Spec.Source.Selector == nil {
+		return corev1.PersistentVolumeClaimList{}, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(vg.Spec.Source.Selector)
 	if err != nil {
-		return corev1.PersistentVolumeClaimList{}, err
+		return corev1.PersistentVolumeClaimList{}, &errors.MatchingLabelsAndLabelSelectorError{ErrorMessage: err.Error()}
+	}
+	return getDriverPVCList(ctx, logger, client, driver, runtimeclient.MatchingLabelsSelector{Selector: selector})
+}
+
+func getNamedPVCList(ctx context.Context, logger logr.Logger, client runtimeclient.Client, driver, namespace string,
+	pvcNames []string) (corev1.PersistentVolumeClaimList, error) {
+	newPVCList := corev1.PersistentVolumeClaimList{}
+	for _, pvcName := range pvcNames {
+		pvc, err := GetPersistentVolumeClaim(ctx, logger, client, pvcName, namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return corev1.PersistentVolumeClaimList{}, err
+		}
+		isPVCOfDriver, err := isBoundPVCOfDriver(ctx, logger, client, pvc, driver)
+		if err != nil {
+			return corev1.PersistentVolumeClaimList{}, err
+		}
+		if isPVCOfDriver {
+			newPVCList.Items = append(newPVCList.Items, *pvc)
+		}
 	}
-	boundPVCList, err := getBoundPVCList(pvcList)
+	return newPVCList, nil
+}
+
+func getDriverPVCList(ctx context.Context, logger logr.Logger, client runtimeclient.Client, driver string,
+	opts ...runtimeclient.ListOption) (corev1.PersistentVolumeClaimList, error) {
+	pvcList, err := getPVCList(ctx, logger, client, opts...)
 	if err != nil {
 		return corev1.PersistentVolumeClaimList{}, err
 	}
+	newPVCList := corev1.PersistentVolumeClaimList{}
+	for i := range pvcList.Items {
+		isPVCOfDriver, err := isBoundPVCOfDriver(ctx, logger, client, &pvcList.Items[i], driver)
+		if err != nil {
+			return corev1.PersistentVolumeClaimList{}, err
+		}
+		if isPVCOfDriver {
+			newPVCList.Items = append(newPVCList.Items, pvcList.Items[i])
+		}
+	}
+	return newPVCList, nil
+}
 
-	return getProvisionedPVCList(logger, client, driver, boundPVCList)
+// isBoundPVCOfDriver checks if the persistentVolumeClaim is bound to a CSI volume of the driver, claims
+// of in-tree volumes that were migrated to the driver cannot be added to a group.
+func isBoundPVCOfDriver(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
+	pvc *corev1.PersistentVolumeClaim, driver string) (bool, error) {
+	if pvc.Status.Phase != corev1.ClaimBound {
+		return false, nil
+	}
+	pvcDriver, err := GetPVCDriver(ctx, logger, client, pvc)
+	if err != nil || pvcDriver != driver {
+		return false, err
+	}
+	err = ValidatePVCVolumeIsCSI(ctx, logger, client, pvc)
+	if errors.IsPersistentVolumeNotCSIError(err) {
+		return false, nil
+	}
+	return err == nil, err
 }
 
-func getPVCList(logger logr.Logger, client runtimeclient.Client) (corev1.PersistentVolumeClaimList, error) {
+func getPVCList(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
+	opts ...runtimeclient.ListOption) (corev1.PersistentVolumeClaimList, error) {
 	logger.Info(messages.ListPersistentVolumeClaim)
 	pvcList := &corev1.PersistentVolumeClaimList{}
-	if err := client.List(context.TODO(), pvcList); err != nil {
+	if err := client.List(ctx, pvcList, opts...); err != nil {
 		logger.Error(err, messages.FailedToListPersistentVolumeClaim)
 		return corev1.PersistentVolumeClaimList{}, err
 	}
 	return *pvcList, nil
 }
 
-func getBoundPVCList(pvcList corev1.PersistentVolumeClaimList) (corev1.PersistentVolumeClaimList, error) {
-	newPVCList := corev1.PersistentVolumeClaimList{}
-	for _, pvc := range pvcList.Items {
-		if pvc.Status.Phase == corev1.ClaimBound {
-			newPVCList.Items = append(newPVCList.Items, pvc)
+// GetPVCDriver returns the driver of the persistentVolumeClaim. The driver of a bound claim is the driver
+// of its persistentVolume, other claims belong to the provisioner of their storageClass. An empty driver
+// is returned for claims without a storageClass or whose storageClass was deleted.
+func GetPVCDriver(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
+	pvc *corev1.PersistentVolumeClaim) (string, error) {
+	if pvc.Spec.VolumeName != "" {
+		pv, err := getPersistentVolume(ctx, logger, client, pvc.Spec.VolumeName)
+		if err == nil {
+			return getPersistentVolumeDriver(pv), nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return "", err
 		}
 	}
-	return newPVCList, nil
+	storageClassName, err := GetPersistentVolumeClaimClass(pvc)
+	if err != nil || storageClassName == "" {
+		return "", nil
+	}
+	provisioner, err := getStorageClassProvisioner(ctx, logger, client, storageClassName)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	return provisioner, err
 }
 
-func getProvisionedPVCList(logger logr.Logger, client runtimeclient.Client, driver string,
-	pvcList corev1.PersistentVolumeClaimList) (corev1.PersistentVolumeClaimList, error) {
-	newPVCList := corev1.PersistentVolumeClaimList{}
-	for _, pvc := range pvcList.Items {
-		isPVCHasMatchingDriver, err := IsPVCHasMatchingDriver(logger, client, &pvc, driver)
+// DeletePersistentVolumeClaimsOfVG deletes the persistentVolumeClaims of a volumeGroup and returns
+// how many of them still exist, claims that are part of other volumeGroups are not deleted. The group
+// on the storage must be emptied before, see RemoveVolumesFromDeletedVolumeGroup.
+func DeletePersistentVolumeClaimsOfVG(ctx context.Context, logger logr.Logger, client runtimeclient.Client, driver string,
+	vg *volumegroupv2.VolumeGroup) (int, error) {
+	vgList, err := GetVGList(ctx, logger, client, driver)
+	if err != nil {
+		return 0, err
+	}
+	remainingPVCs := 0
+	for _, member := range vg.Status.Members {
+		pvc, err := GetPersistentVolumeClaim(ctx, logger, client, member.Name, member.Namespace)
 		if err != nil {
-			return corev1.PersistentVolumeClaimList{}, err
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return 0, err
+		}
+		if isPVCPartOfOtherVG(pvc, vg, vgList.Items) {
+			logger.Info(fmt.Sprintf(messages.PersistentVolumeClaimIsPartOfOtherVolumeGroups,
+				pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+			continue
 		}
-		if isPVCHasMatchingDriver {
-			newPVCList.Items = append(newPVCList.Items, pvc)
+		if err = deletePersistentVolumeClaim(ctx, logger, client, pvc, vg); err != nil {
+			return 0, err
 		}
+		remainingPVCs++
 	}
-	return newPVCList, nil
+	return remainingPVCs, nil
 }
 
-func IsPVCHasMatchingDriver(logger logr.Logger, client runtimeclient.Client,
-	pvc *corev1.PersistentVolumeClaim, driver string) (bool, error) {
-	storageClassName, sErr := GetPersistentVolumeClaimClass(pvc)
-	if sErr != nil {
-		return false, sErr
+func isPVCPartOfOtherVG(pvc *corev1.PersistentVolumeClaim, vg *volumegroupv2.VolumeGroup,
+	vgs []volumegroupv2.VolumeGroup) bool {
+	for _, otherVG := range vgs {
+		if otherVG.UID != vg.UID && IsPVCPartOfVG(pvc, otherVG.Status.Members) {
+			return true
+		}
 	}
-	scProvisioner, err := getStorageClassProvisioner(logger, client, storageClassName)
-	if err != nil {
-		return false, err
+	return false
+}
+
+// deletePersistentVolumeClaim deletes the claim and removes the volumeGroup finalizer from it,
+// other finalizers, like the kubernetes pvc-protection, are left for their owners.
+func deletePersistentVolumeClaim(ctx context.Context, logger logr.Logger, client runtimeclient.Client,
+	pvc *corev1.PersistentVolumeClaim, vg *volumegroupv2.VolumeGroup) error {
+	if pvc.GetDeletionTimestamp().IsZero() {
+		logger.Info(fmt.Sprintf(messages.DeletePersistentVolumeClaim, pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
+		if err := client.Delete(ctx, pvc); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, fmt.Sprintf(messages.FailedToDeletePersistentVolumeClaim, pvc.Namespace, pvc.Name))
+			return err
+		}
 	}
-	return scProvisioner == driver, nil
+	return removeVolumeGroupFinalizerFromPVC(ctx, logger, client, pvc)
 }
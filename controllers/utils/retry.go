@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"time"
+
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+// DriverDisconnectedRequeueInterval is how often a reconcile is retried while the connection to the driver is down.
+const DriverDisconnectedRequeueInterval = 10 * time.Second
+
+// NewDriverRetryRateLimiter returns the rate limiter of controllers that call the driver, failed
+// requests are retried with exponential backoff between retryIntervalStart and retryIntervalMax.
+func NewDriverRetryRateLimiter(retryIntervalStart, retryIntervalMax time.Duration) ratelimiter.RateLimiter {
+	return workqueue.NewItemExponentialFailureRateLimiter(retryIntervalStart, retryIntervalMax)
+}
+
+// IgnoreTerminalDriverError stops the retries of a request that the driver rejected with a terminal
+// error, the failed condition is already set and the request is reconciled again when it changes.
+// VolumeGroups are also reconciled again when their volumeGroupClass changes.
+func IgnoreTerminalDriverError(logger logr.Logger, err error) error {
+	if vgerrors.IsTerminalDriverError(err) {
+		logger.Info(messages.TerminalDriverErrorIsNotRetried, "error", err.Error())
+		return nil
+	}
+	return err
+}
+
+// IsDriverDisconnected reports whether the connection to the driver is down, the reconcile should then
+// be requeued instead of failing requests to the driver.
+func IsDriverDisconnected(logger logr.Logger, client *grpcClient.Client) bool {
+	if client.IsConnected() {
+		return false
+	}
+	logger.Info(messages.DriverIsDisconnected)
+	return true
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -27,10 +29,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func getSecretData(client client.Client, logger logr.Logger, name, namespace string) (map[string]string, error) {
+func getSecretData(ctx context.Context, client client.Client, logger logr.Logger, name, namespace string) (map[string]string, error) {
 	namespacedName := types.NamespacedName{Name: name, Namespace: namespace}
 	secret := &corev1.Secret{}
-	err := client.Get(context.TODO(), namespacedName, secret)
+	err := client.Get(ctx, namespacedName, secret)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error(err, "secret not found", "Secret Name", name, "Secret Namespace", namespace)
@@ -55,14 +57,14 @@ func convertMap(oldMap map[string][]byte) map[string]string {
 	return newMap
 }
 
-func GetSecretDataFromClass(client client.Client, vgcObj *volumegroupv1.VolumeGroupClass, logger logr.Logger, instance *volumegroupv1.VolumeGroup) (map[string]string, error) {
+func GetSecretDataFromClass(ctx context.Context, client client.Client, vgcObj *volumegroupv1.VolumeGroupClass, logger logr.Logger, instance *volumegroupv2.VolumeGroup) (map[string]string, error) {
 	secretName, secretNamespace := GetSecretCred(vgcObj)
 	secret := make(map[string]string)
 	var err error
 	if secretName != "" && secretNamespace != "" {
-		secret, err = getSecretData(client, logger, secretName, secretNamespace)
+		secret, err = getSecretData(ctx, client, logger, secretName, secretNamespace)
 		if err != nil {
-			if uErr := UpdateVolumeGroupStatusError(client, instance, logger, err.Error()); uErr != nil {
+			if uErr := UpdateVolumeGroupStatusCondition(ctx, client, instance, logger, err, vgReconcile); uErr != nil {
 				return nil, uErr
 			}
 			return nil, err
@@ -76,3 +78,43 @@ func GetSecretCred(vgcObj *volumegroupv1.VolumeGroupClass) (string, string) {
 	secretNamespace := vgcObj.Parameters[PrefixedVolumeGroupSecretNamespaceKey]
 	return secretName, secretNamespace
 }
+
+// GetVolumeGroupClassesSecrets returns the distinct secrets of the volumeGroupClasses of the driver,
+// an empty secret is returned for classes without a secret.
+func GetVolumeGroupClassesSecrets(ctx context.Context, client client.Client, logger logr.Logger, driver string) ([]map[string]string, error) {
+	vgClassList := &volumegroupv1.VolumeGroupClassList{}
+	if err := client.List(ctx, vgClassList); err != nil {
+		logger.Error(err, messages.FailedToListVolumeGroupClasses)
+		return nil, err
+	}
+
+	secrets := []map[string]string{}
+	seenSecretRefs := map[types.NamespacedName]bool{}
+	for _, vgClass := range vgClassList.Items {
+		if vgClass.Driver != driver {
+			continue
+		}
+		secretName, secretNamespace := GetSecretCred(&vgClass)
+		if secretName == "" || secretNamespace == "" {
+			secretName, secretNamespace = "", ""
+		}
+		secretRef := types.NamespacedName{Name: secretName, Namespace: secretNamespace}
+		if seenSecretRefs[secretRef] {
+			continue
+		}
+		seenSecretRefs[secretRef] = true
+		if secretName == "" {
+			secrets = append(secrets, map[string]string{})
+			continue
+		}
+		secret, err := getSecretData(ctx, client, logger, secretName, secretNamespace)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	if len(secrets) == 0 {
+		secrets = append(secrets, map[string]string{})
+	}
+	return secrets, nil
+}
@@ -9,14 +9,34 @@ const (
 	pvcVolumeGroupFinalizer               = VolumeGroupAsPrefix + "pvc-protection"
 	PrefixedVolumeGroupSecretNameKey      = VolumeGroupAsPrefix + "secret-name"      // name key for secret
 	PrefixedVolumeGroupSecretNamespaceKey = VolumeGroupAsPrefix + "secret-namespace" // namespace key secret
+	IsDefaultVolumeGroupClassAnnotation   = VolumeGroupAsPrefix + "is-default-class"
+	VolumeGroupNameAnnotation             = VolumeGroupAsPrefix + "volume-group-name"
+	VolumeGroupHandleAnnotation           = VolumeGroupAsPrefix + "volume-group-handle"
 	letterBytes                           = "0123456789abcdefghijklmnopqrstuvwxyz"
 	volumeGroupController                 = "volumeGroupController"
 	warningEventType                      = "Warning"
 	normalEventType                       = "Normal"
 	storageClassVGParameter               = "volume_group"
+	pvMigratedToAnnotation                = "pv.kubernetes.io/migrated-to"
 	addingPVC                             = "addPVC"
 	removingPVC                           = "removePVC"
+	modifyingPVCs                         = "modifyPVCs"
 	createVGC                             = "creatingVGC"
+	createVG                              = "creatingVG"
+	deleteVG                              = "deletingVG"
+	deletePVCs                            = "deletingPVCs"
+	membershipDrift                       = "membershipDrift"
+	waitingForPVCs                        = "WaitingForPersistentVolumeClaims"
+	updateVGC                             = "updatingVGC"
+	updateStatusVG                        = "updatingStatusVG"
+	updateStatusVGC                       = "updatingStatusVGC"
+	vgReconcile                           = "vgReconcile"
+	invalidParameters                     = "invalidParameters"
+	bindingSucceeded                      = "Bound"
+	bindingReleased                       = "Released"
+	reconcileSucceeded                    = "Reconciled"
+	bindingAvailable                      = "Available"
+	driverResponded                       = "DriverResponded"
 	volumeGroupContentKind                = "VolumeGroupContent"
-	APIVersion                            = "csi.ibm.com/v1"
+	APIVersion                            = "csi.ibm.com/v2"
 )
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListStorageVolumeGroups returns all the volume groups that the driver lists with the secrets,
+// following the pages of the response.
+func ListStorageVolumeGroups(ctx context.Context, logger logr.Logger, vgClient grpcClient.VolumeGroup,
+	secrets map[string]string) ([]*csi.VolumeGroup, error) {
+	volumeGroups := []*csi.VolumeGroup{}
+	params := volumegroup.CommonRequestParameters{
+		Secrets:     secrets,
+		VolumeGroup: vgClient,
+	}
+	logger.Info(messages.ListStorageVolumeGroups)
+	for {
+		listVolumeGroupsResponse := volumegroup.NewVolumeGroupRequest(params).List(ctx)
+		if listVolumeGroupsResponse.Error != nil {
+			return nil, listVolumeGroupsResponse.Error
+		}
+		resp := listVolumeGroupsResponse.Response.(*csi.ListVolumeGroupsResponse)
+		for _, entry := range resp.GetEntries() {
+			if entry.GetVolumeGroup() != nil {
+				volumeGroups = append(volumeGroups, entry.GetVolumeGroup())
+			}
+		}
+		if resp.GetNextToken() == "" {
+			return volumeGroups, nil
+		}
+		params.StartingToken = resp.GetNextToken()
+	}
+}
+
+// GetStorageVolumeGroup returns the group with the volumeGroupId from the storage, only the ID is
+// returned when the driver cannot get the group.
+func GetStorageVolumeGroup(ctx context.Context, logger logr.Logger, vgClient grpcClient.VolumeGroup, volumeGroupId string,
+	secrets map[string]string) (*csi.VolumeGroup, error) {
+	params := volumegroup.CommonRequestParameters{
+		VolumeGroupID: volumeGroupId,
+		Secrets:       secrets,
+		VolumeGroup:   vgClient,
+	}
+	logger.Info(fmt.Sprintf(messages.GetVolumeGroup, volumeGroupId))
+	getVolumeGroupResponse := volumegroup.NewVolumeGroupRequest(params).Get(ctx)
+	if getVolumeGroupResponse.HasKnownGRPCError([]codes.Code{codes.Unimplemented}) {
+		return &csi.VolumeGroup{VolumeGroupId: volumeGroupId}, nil
+	}
+	if getVolumeGroupResponse.Error != nil {
+		logger.Error(getVolumeGroupResponse.Error, fmt.Sprintf(messages.FailedToGetVolumeGroup, volumeGroupId))
+		return nil, getVolumeGroupResponse.Error
+	}
+	return getVolumeGroupResponse.Response.(*csi.ControllerGetVolumeGroupResponse).GetVolumeGroup(), nil
+}
+
+// FindStorageVolumeGroupByName returns the group that the driver created with the name, drivers that
+// do not use the name as the group ID may return it in the group context. It returns nil when no
+// group has the name or when the driver cannot list groups.
+func FindStorageVolumeGroupByName(ctx context.Context, logger logr.Logger, vgClient grpcClient.VolumeGroup, name string,
+	secrets map[string]string) (*csi.VolumeGroup, error) {
+	volumeGroups, err := ListStorageVolumeGroups(ctx, logger, vgClient, secrets)
+	if status.Code(err) == codes.Unimplemented {
+		logger.Info(fmt.Sprintf(messages.VolumeGroupListIsNotSupportedOnResume, name))
+		return nil, nil
+	}
+	if err != nil {
+		logger.Error(err, messages.FailedToListStorageVolumeGroups)
+		return nil, err
+	}
+	for _, vg := range volumeGroups {
+		if vg.GetVolumeGroupId() == name {
+			return vg, nil
+		}
+		for _, value := range vg.GetVolumeGroupContext() {
+			if value == name {
+				return vg, nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"context"
+
 	"fmt"
 	corev1 "k8s.io/api/core/v1"
 
@@ -25,8 +26,8 @@ func GetPersistentVolumeClaimClass(claim *corev1.PersistentVolumeClaim) (string,
 	return "", err
 }
 
-func getStorageClassProvisioner(logger logr.Logger, client client.Client, scName string) (string, error) {
-	sc, err := getStorageClass(logger, client, scName)
+func getStorageClassProvisioner(ctx context.Context, logger logr.Logger, client client.Client, scName string) (string, error) {
+	sc, err := getStorageClass(ctx, logger, client, scName)
 	if err != nil {
 		return "", err
 	}
@@ -39,9 +40,9 @@ func isSCHasParam(sc *storagev1.StorageClass, param string) bool {
 	return ok
 }
 
-func getStorageClass(logger logr.Logger, client client.Client, scName string) (*storagev1.StorageClass, error) {
+func getStorageClass(ctx context.Context, logger logr.Logger, client client.Client, scName string) (*storagev1.StorageClass, error) {
 	sc := &storagev1.StorageClass{}
-	err := client.Get(context.TODO(), types.NamespacedName{Name: scName}, sc)
+	err := client.Get(ctx, types.NamespacedName{Name: scName}, sc)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf(messages.FailedToGetStorageClass, scName))
 		return nil, err
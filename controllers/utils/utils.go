@@ -17,14 +17,13 @@ limitations under the License.
 package utils
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 )
 
-func removeByIndexFromPVCList(pvcList []corev1.PersistentVolumeClaim, index int) []corev1.PersistentVolumeClaim {
-	return append(pvcList[:index], pvcList[index+1:]...)
+func removeByIndexFromMembers(members []volumegroupv2.VolumeGroupMember, index int) []volumegroupv2.VolumeGroupMember {
+	return append(members[:index], members[index+1:]...)
 }
 
-func removeByIndexFromPersistentVolumeList(pvList []corev1.PersistentVolume,
-	index int) []corev1.PersistentVolume {
-	return append(pvList[:index], pvList[index+1:]...)
+func copyMembers(members []volumegroupv2.VolumeGroupMember) []volumegroupv2.VolumeGroupMember {
+	return append([]volumegroupv2.VolumeGroupMember{}, members...)
 }
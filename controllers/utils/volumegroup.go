@@ -20,29 +20,45 @@ import (
 	"context"
 	"fmt"
 
-	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func UpdateVolumeGroupSourceContent(client client.Client, instance *volumegroupv1.VolumeGroup,
+func UpdateVolumeGroupSourceContent(ctx context.Context, client client.Client, instance *volumegroupv2.VolumeGroup,
 	vgcName string, logger logr.Logger) error {
 	instance.Spec.Source.VolumeGroupContentName = &vgcName
-	if err := UpdateObject(client, instance); err != nil {
+	if err := UpdateObject(ctx, client, instance); err != nil {
 		logger.Error(err, "failed to update source", "VGName", instance.Name)
 		return err
 	}
 	return nil
 }
 
-func updateVolumeGroupStatus(client client.Client, instance *volumegroupv1.VolumeGroup, logger logr.Logger) error {
+func SetDefaultVolumeGroupClass(ctx context.Context, client client.Client, logger logr.Logger, vg *volumegroupv2.VolumeGroup,
+	drivers []string) error {
+	vgClass, err := GetDefaultVolumeGroupClass(ctx, client, logger, drivers)
+	if err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf(messages.SetDefaultVolumeGroupClass, vg.Namespace, vg.Name, vgClass.Name))
+	vg.Spec.VolumeGroupClassName = &vgClass.Name
+	if err := UpdateObject(ctx, client, vg); err != nil {
+		logger.Error(err, "failed to set default volumeGroupClass", "VGName", vg.Name)
+		return err
+	}
+	return nil
+}
+
+func updateVolumeGroupStatus(ctx context.Context, client client.Client, instance *volumegroupv2.VolumeGroup, logger logr.Logger) error {
 	logger.Info(fmt.Sprintf(messages.UpdateVolumeGroupStatus, instance.Namespace, instance.Name))
-	if err := UpdateObjectStatus(client, instance); err != nil {
+	if err := UpdateObjectStatus(ctx, client, instance); err != nil {
 		if apierrors.IsConflict(err) {
 			return err
 		}
@@ -52,28 +68,45 @@ func updateVolumeGroupStatus(client client.Client, instance *volumegroupv1.Volum
 	return nil
 }
 
-func UpdateVolumeGroupStatus(client client.Client, vg *volumegroupv1.VolumeGroup, vgc *volumegroupv1.VolumeGroupContent,
-	groupCreationTime *metav1.Time, ready bool, logger logr.Logger) error {
+func UpdateVolumeGroupStatus(ctx context.Context, client client.Client, vg *volumegroupv2.VolumeGroup, vgc *volumegroupv2.VolumeGroupContent,
+	groupCreationTime *metav1.Time, logger logr.Logger) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		vg.Status.BoundVolumeGroupContentName = &vgc.Name
 		vg.Status.GroupCreationTime = groupCreationTime
-		vg.Status.Ready = &ready
-		vg.Status.Error = nil
-		err := vgRetryOnConflictFunc(client, vg, logger)
+		vg.Status.ObservedGeneration = vg.Generation
+		setBoundCondition(&vg.Status.Conditions, vg.Generation, fmt.Sprintf(messages.VolumeGroupBound, vgc.Name))
+		setReconciledCondition(&vg.Status.Conditions, vg.Generation)
+		err := vgRetryOnConflictFunc(ctx, client, vg, logger)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return updateVolumeGroupStatus(ctx, client, vg, logger)
+}
+
+func updateVolumeGroupStatusMembers(ctx context.Context, client client.Client, vg *volumegroupv2.VolumeGroup, logger logr.Logger,
+	members []volumegroupv2.VolumeGroupMember) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vg.Status.Members = members
+		err := vgRetryOnConflictFunc(ctx, client, vg, logger)
 		return err
 	})
 	if err != nil {
 		return err
 	}
 
-	return updateVolumeGroupStatus(client, vg, logger)
+	return nil
 }
 
-func updateVolumeGroupStatusPVCList(client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger,
-	pvcList []corev1.PersistentVolumeClaim) error {
+func UpdateVolumeGroupStatusCondition(ctx context.Context, client client.Client, vg *volumegroupv2.VolumeGroup, logger logr.Logger,
+	vgErr error, reason string) error {
+	message := GetMessageFromError(vgErr)
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		vg.Status.PVCList = pvcList
-		err := vgRetryOnConflictFunc(client, vg, logger)
+		vg.Status.ObservedGeneration = vg.Generation
+		setFailedCondition(&vg.Status.Conditions, vg.Generation, vgErr, reason, message)
+		err := vgRetryOnConflictFunc(ctx, client, vg, logger)
 		return err
 	})
 	if err != nil {
@@ -83,10 +116,12 @@ func updateVolumeGroupStatusPVCList(client client.Client, vg *volumegroupv1.Volu
 	return nil
 }
 
-func UpdateVolumeGroupStatusError(client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger, message string) error {
+func updateVolumeGroupStatusSucceededCondition(ctx context.Context, client client.Client, vg *volumegroupv2.VolumeGroup, logger logr.Logger,
+	message, reason string) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		vg.Status.Error = &volumegroupv1.VolumeGroupError{Message: &message}
-		err := vgRetryOnConflictFunc(client, vg, logger)
+		vg.Status.ObservedGeneration = vg.Generation
+		setSucceededCondition(&vg.Status.Conditions, vg.Generation, reason, message)
+		err := vgRetryOnConflictFunc(ctx, client, vg, logger)
 		return err
 	})
 	if err != nil {
@@ -96,10 +131,38 @@ func UpdateVolumeGroupStatusError(client client.Client, vg *volumegroupv1.Volume
 	return nil
 }
 
-func vgRetryOnConflictFunc(client client.Client, vg *volumegroupv1.VolumeGroup, logger logr.Logger) error {
-	err := updateVolumeGroupStatus(client, vg, logger)
+func UpdateVolumeGroupDeletionProgress(ctx context.Context, client client.Client, vg *volumegroupv2.VolumeGroup, logger logr.Logger,
+	remainingPVCs int) error {
+	message := fmt.Sprintf(messages.WaitingForPersistentVolumeClaimsDeletion, remainingPVCs)
+	logger.Info(message)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vg.Status.ObservedGeneration = vg.Generation
+		setCondition(&vg.Status.Conditions, vg.Generation, volumegroupv2.ConditionDeletionBlocked, metav1.ConditionTrue,
+			waitingForPVCs, message)
+		return vgRetryOnConflictFunc(ctx, client, vg, logger)
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func updateVolumeGroupMembers(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv2.VolumeGroup,
+	members []volumegroupv2.VolumeGroupMember) error {
+	previousMembers := vg.Status.Members
+	err := updateVolumeGroupStatusMembers(ctx, client, vg, logger, members)
+	if err != nil {
+		vg.Status.Members = previousMembers
+		logger.Error(err, fmt.Sprintf(messages.FailedToUpdatePersistentVolumeClaimsOfVolumeGroup, vg.Namespace, vg.Name))
+		return err
+	}
+	return nil
+}
+
+func vgRetryOnConflictFunc(ctx context.Context, client client.Client, vg *volumegroupv2.VolumeGroup, logger logr.Logger) error {
+	err := updateVolumeGroupStatus(ctx, client, vg, logger)
 	if apierrors.IsConflict(err) {
-		uErr := getNamespacedObject(client, vg)
+		uErr := getNamespacedObject(ctx, client, vg)
 		if uErr != nil {
 			return uErr
 		}
@@ -108,55 +171,128 @@ func vgRetryOnConflictFunc(client client.Client, vg *volumegroupv1.VolumeGroup,
 	return err
 }
 
-func GetVGList(logger logr.Logger, client client.Client, driver string) (volumegroupv1.VolumeGroupList, error) {
+// GetVGList returns the volumeGroups of the driver, they are listed by the volumeGroupClasses of the driver.
+func GetVGList(ctx context.Context, logger logr.Logger, client client.Client, driver string) (volumegroupv2.VolumeGroupList, error) {
 	logger.Info(messages.ListVolumeGroups)
-	vg := &volumegroupv1.VolumeGroupList{}
-	err := client.List(context.TODO(), vg)
+	vgClassNames, err := getVolumeGroupClassNamesOfDriver(ctx, client, logger, driver)
 	if err != nil {
-		return volumegroupv1.VolumeGroupList{}, err
+		return volumegroupv2.VolumeGroupList{}, err
 	}
-	vgList, err := getProvisionedVGs(logger, client, vg, driver)
-	if err != nil {
-		return volumegroupv1.VolumeGroupList{}, err
+	vgList := volumegroupv2.VolumeGroupList{}
+	for _, vgClassName := range vgClassNames {
+		classVGList := &volumegroupv2.VolumeGroupList{}
+		if err = client.List(ctx, classVGList, matchingVolumeGroupClass(vgClassName)); err != nil {
+			logger.Error(err, messages.FailedToListVolumeGroups)
+			return volumegroupv2.VolumeGroupList{}, err
+		}
+		vgList.Items = append(vgList.Items, classVGList.Items...)
 	}
 	return vgList, nil
 }
 
-func getProvisionedVGs(logger logr.Logger, client client.Client, vgList *volumegroupv1.VolumeGroupList,
-	driver string) (volumegroupv1.VolumeGroupList, error) {
-	newVgList := volumegroupv1.VolumeGroupList{}
-	for _, vg := range vgList.Items {
-		isVGHasMatchingDriver, err := isVGHasMatchingDriver(logger, client, vg, driver)
-		if err != nil {
-			return volumegroupv1.VolumeGroupList{}, err
-		}
-		if isVGHasMatchingDriver {
-			newVgList.Items = append(newVgList.Items, vg)
-		}
+// GetVGListOfVolumeGroupClass returns the volumeGroups that use the volumeGroupClass.
+func GetVGListOfVolumeGroupClass(ctx context.Context, logger logr.Logger, client client.Client,
+	vgClassName string) (volumegroupv2.VolumeGroupList, error) {
+	logger.Info(messages.ListVolumeGroups)
+	vgList := &volumegroupv2.VolumeGroupList{}
+	if err := client.List(ctx, vgList, matchingVolumeGroupClass(vgClassName)); err != nil {
+		logger.Error(err, messages.FailedToListVolumeGroups)
+		return volumegroupv2.VolumeGroupList{}, err
 	}
-	return newVgList, nil
+	return *vgList, nil
 }
 
-func isVGHasMatchingDriver(logger logr.Logger, client client.Client, vg volumegroupv1.VolumeGroup,
-	driver string) (bool, error) {
-	vgClassDriver, err := getVGClassDriver(client, logger, *vg.Spec.VolumeGroupClassName)
+// GetVGListOfPVC returns the volumeGroups that have the persistentVolumeClaim in their status.
+func GetVGListOfPVC(ctx context.Context, logger logr.Logger, client client.Client,
+	pvc *corev1.PersistentVolumeClaim) (volumegroupv2.VolumeGroupList, error) {
+	logger.Info(messages.ListVolumeGroups)
+	vgList := &volumegroupv2.VolumeGroupList{}
+	if err := client.List(ctx, vgList, matchingMemberPVC(pvc)); err != nil {
+		logger.Error(err, messages.FailedToListVolumeGroups)
+		return volumegroupv2.VolumeGroupList{}, err
+	}
+	return *vgList, nil
+}
+
+// GetVGsAffectedByPVC returns the volumeGroups whose membership may change with the persistentVolumeClaim,
+// the groups that have it in their status, the groups that name it and the groups of the driver in its
+// namespace whose selector selects it. Every list is served by a cache index, so the cost of a
+// persistentVolumeClaim event does not grow with the number of volumeGroups in other namespaces.
+func GetVGsAffectedByPVC(ctx context.Context, logger logr.Logger, client client.Client, driver string,
+	pvc *corev1.PersistentVolumeClaim) ([]volumegroupv2.VolumeGroup, error) {
+	vgClassNames, err := getVolumeGroupClassNamesOfDriver(ctx, client, logger, driver)
+	if err != nil {
+		return nil, err
+	}
+	memberVGList, err := GetVGListOfPVC(ctx, logger, client, pvc)
+	if err != nil {
+		return nil, err
+	}
+	namedVGList, err := getVGListNamingPVC(ctx, logger, client, pvc)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			return false, nil
+		return nil, err
+	}
+	selectingVGs := []volumegroupv2.VolumeGroup{}
+	for _, vg := range namedVGList.Items {
+		if len(vg.Spec.Source.PersistentVolumeClaimNames) > 0 && vg.Spec.VolumeGroupClassName != nil &&
+			Contains(vgClassNames, *vg.Spec.VolumeGroupClassName) {
+			selectingVGs = append(selectingVGs, vg)
+		}
+	}
+	for _, vgClassName := range vgClassNames {
+		classVGList := &volumegroupv2.VolumeGroupList{}
+		if err = client.List(ctx, classVGList, matchingVolumeGroupClassInNamespace(vgClassName, pvc.Namespace)...); err != nil {
+			logger.Error(err, messages.FailedToListVolumeGroups)
+			return nil, err
+		}
+		for _, vg := range classVGList.Items {
+			if vg.Spec.Source.Selector != nil {
+				selectingVGs = append(selectingVGs, vg)
+			}
 		}
-		return false, err
 	}
-	return vgClassDriver == driver, nil
+
+	vgs := []volumegroupv2.VolumeGroup{}
+	seenVGs := map[types.NamespacedName]bool{}
+	addVG := func(vg volumegroupv2.VolumeGroup) {
+		vgKey := types.NamespacedName{Name: vg.Name, Namespace: vg.Namespace}
+		if !seenVGs[vgKey] {
+			seenVGs[vgKey] = true
+			vgs = append(vgs, vg)
+		}
+	}
+	for _, vg := range memberVGList.Items {
+		if IsPVCPartOfVG(pvc, vg.Status.Members) {
+			addVG(vg)
+		}
+	}
+	for _, vg := range selectingVGs {
+		if isPVCMatchesVG, _ := isPVCMatchesVGSource(client, pvc, vg); isPVCMatchesVG {
+			addVG(vg)
+		}
+	}
+	return vgs, nil
 }
-func IsPVCMatchesVG(logger logr.Logger, client client.Client,
-	pvc *corev1.PersistentVolumeClaim, vg volumegroupv1.VolumeGroup) (bool, error) {
+
+// getVGListNamingPVC returns the volumeGroups that list the persistentVolumeClaim in their source.
+func getVGListNamingPVC(ctx context.Context, logger logr.Logger, client client.Client,
+	pvc *corev1.PersistentVolumeClaim) (volumegroupv2.VolumeGroupList, error) {
+	vgList := &volumegroupv2.VolumeGroupList{}
+	if err := client.List(ctx, vgList, matchingPVCName(pvc)); err != nil {
+		logger.Error(err, messages.FailedToListVolumeGroups)
+		return volumegroupv2.VolumeGroupList{}, err
+	}
+	return *vgList, nil
+}
+
+func IsPVCMatchesVG(ctx context.Context, logger logr.Logger, client client.Client,
+	pvc *corev1.PersistentVolumeClaim, vg volumegroupv2.VolumeGroup) (bool, error) {
 
 	logger.Info(fmt.Sprintf(messages.CheckIfPersistentVolumeClaimMatchesVolumeGroup,
 		pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
-	areLabelsMatchLabelSelector, err := areLabelsMatchLabelSelector(
-		client, pvc.ObjectMeta.Labels, *vg.Spec.Source.Selector)
+	isPVCMatches, err := isPVCMatchesVGSource(client, pvc, vg)
 
-	if areLabelsMatchLabelSelector {
+	if isPVCMatches {
 		logger.Info(fmt.Sprintf(messages.PersistentVolumeClaimMatchedToVolumeGroup,
 			pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
 		return true, err
@@ -167,96 +303,142 @@ func IsPVCMatchesVG(logger logr.Logger, client client.Client,
 	}
 }
 
-func RemovePVCFromVG(logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
-	logger.Info(fmt.Sprintf(messages.RemovePersistentVolumeClaimFromVolumeGroup,
-		pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
-	vg.Status.PVCList = removeFromPVCList(pvc, vg.Status.PVCList)
-	err := updateVolumeGroupStatusPVCList(client, vg, logger, vg.Status.PVCList)
-	if err != nil {
-		vg.Status.PVCList = appendPVC(vg.Status.PVCList, *pvc)
-		logger.Error(err, fmt.Sprintf(messages.FailedToRemovePersistentVolumeClaimFromVolumeGroup,
-			pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
-		return err
+func isPVCMatchesVGSource(client client.Client, pvc *corev1.PersistentVolumeClaim,
+	vg volumegroupv2.VolumeGroup) (bool, error) {
+	if len(vg.Spec.Source.PersistentVolumeClaimNames) > 0 {
+		return pvc.Namespace == vg.Namespace && Contains(vg.Spec.Source.PersistentVolumeClaimNames, pvc.Name), nil
 	}
-	logger.Info(fmt.Sprintf(messages.RemovedPersistentVolumeClaimFromVolumeGroup,
-		pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
-	return nil
+	if vg.Spec.Source.Selector == nil {
+		return false, nil
+	}
+	return areLabelsMatchLabelSelector(client, pvc.ObjectMeta.Labels, *vg.Spec.Source.Selector)
 }
 
-func removeMultiplePVCs(pvcList []corev1.PersistentVolumeClaim,
-	pvcs []corev1.PersistentVolumeClaim) []corev1.PersistentVolumeClaim {
+func removeMultiplePVCs(members []volumegroupv2.VolumeGroupMember,
+	pvcs []corev1.PersistentVolumeClaim) []volumegroupv2.VolumeGroupMember {
 	for _, pvc := range pvcs {
-		pvcList = removeFromPVCList(&pvc, pvcList)
+		members = removeFromMembers(&pvc, members)
 	}
-	return pvcList
+	return members
 }
 
-func removeFromPVCList(pvc *corev1.PersistentVolumeClaim, pvcList []corev1.PersistentVolumeClaim) []corev1.PersistentVolumeClaim {
-	for index, pvcFromList := range pvcList {
-		if pvcFromList.Name == pvc.Name && pvcFromList.Namespace == pvc.Namespace {
-			pvcList = removeByIndexFromPVCList(pvcList, index)
-			return pvcList
+func removeFromMembers(pvc *corev1.PersistentVolumeClaim, members []volumegroupv2.VolumeGroupMember) []volumegroupv2.VolumeGroupMember {
+	for index, member := range members {
+		if isMemberOfPVC(member, pvc) {
+			return removeByIndexFromMembers(members, index)
 		}
 	}
-	return pvcList
+	return members
 }
 
-func getVgId(logger logr.Logger, client client.Client, vg *volumegroupv1.VolumeGroup) (string, error) {
-	vgc, err := GetVolumeGroupContent(client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Name, vg.Namespace)
+func getVgId(ctx context.Context, logger logr.Logger, client client.Client, vg *volumegroupv2.VolumeGroup) (string, error) {
+	vgc, err := GetVolumeGroupContent(ctx, client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Name, vg.Namespace)
 	if err != nil {
 		return "", err
 	}
 	return string(vgc.Spec.Source.VolumeGroupHandle), nil
 }
 
-func AddPVCToVG(logger logr.Logger, client client.Client, pvc *corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
-	logger.Info(fmt.Sprintf(messages.AddPersistentVolumeClaimToVolumeGroup,
-		pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
-	vg.Status.PVCList = appendPVC(vg.Status.PVCList, *pvc)
-	err := updateVolumeGroupStatusPVCList(client, vg, logger, vg.Status.PVCList)
-	if err != nil {
-		vg.Status.PVCList = removeFromPVCList(pvc, vg.Status.PVCList)
-		logger.Error(err, fmt.Sprintf(messages.FailedToAddPersistentVolumeClaimToVolumeGroup,
-			pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
-		return err
+func appendMultipleMembers(members []volumegroupv2.VolumeGroupMember,
+	newMembers []volumegroupv2.VolumeGroupMember) []volumegroupv2.VolumeGroupMember {
+	for _, member := range newMembers {
+		members = appendMember(members, member)
 	}
-	logger.Info(fmt.Sprintf(messages.AddedPersistentVolumeClaimToVolumeGroup,
-		pvc.Namespace, pvc.Name, vg.Namespace, vg.Name))
-	return nil
+	return members
 }
 
-func appendMultiplePVCs(pvcListInVG []corev1.PersistentVolumeClaim,
-	pvcs []corev1.PersistentVolumeClaim) []corev1.PersistentVolumeClaim {
-	for _, pvc := range pvcs {
-		pvcListInVG = appendPVC(pvcListInVG, pvc)
-	}
-	return pvcListInVG
-}
-
-func appendPVC(pvcListInVG []corev1.PersistentVolumeClaim, pvc corev1.PersistentVolumeClaim) []corev1.PersistentVolumeClaim {
-	for _, pvcFromList := range pvcListInVG {
-		if pvcFromList.Name == pvc.Name && pvcFromList.Namespace == pvc.Namespace {
-			return pvcListInVG
+func appendMember(members []volumegroupv2.VolumeGroupMember, newMember volumegroupv2.VolumeGroupMember) []volumegroupv2.VolumeGroupMember {
+	for _, member := range members {
+		if member.Name == newMember.Name && member.Namespace == newMember.Namespace {
+			return members
 		}
 	}
-	pvcListInVG = append(pvcListInVG, pvc)
-	return pvcListInVG
+	return append(members, newMember)
 }
 
-func IsPVCPartAnyVG(pvc *corev1.PersistentVolumeClaim, vgs []volumegroupv1.VolumeGroup) bool {
+func IsPVCPartAnyVG(pvc *corev1.PersistentVolumeClaim, vgs []volumegroupv2.VolumeGroup) bool {
 	for _, vg := range vgs {
-		if IsPVCPartOfVG(pvc, vg.Status.PVCList) {
+		if IsPVCPartOfVG(pvc, vg.Status.Members) {
 			return true
 		}
 	}
 	return false
 }
 
-func IsPVCPartOfVG(pvc *corev1.PersistentVolumeClaim, pvcList []corev1.PersistentVolumeClaim) bool {
-	for _, pvcFromList := range pvcList {
-		if pvcFromList.Name == pvc.Name && pvcFromList.Namespace == pvc.Namespace {
+func IsPVCPartOfVG(pvc *corev1.PersistentVolumeClaim, members []volumegroupv2.VolumeGroupMember) bool {
+	for _, member := range members {
+		if isMemberOfPVC(member, pvc) {
 			return true
 		}
 	}
 	return false
 }
+
+func isMemberOfPVC(member volumegroupv2.VolumeGroupMember, pvc *corev1.PersistentVolumeClaim) bool {
+	return member.Name == pvc.Name && member.Namespace == pvc.Namespace
+}
+
+// getVolumeGroupMembers returns the member references of the persistentVolumeClaims, the volume
+// handle is kept in the reference so the group can be modified without reading the persistentVolumes.
+func getVolumeGroupMembers(ctx context.Context, logger logr.Logger, client client.Client,
+	pvcs []corev1.PersistentVolumeClaim) ([]volumegroupv2.VolumeGroupMember, error) {
+	addedTime := metav1.Now()
+	members := []volumegroupv2.VolumeGroupMember{}
+	for i := range pvcs {
+		pv, err := GetPVFromPVC(ctx, logger, client, &pvcs[i])
+		if err != nil {
+			return nil, err
+		}
+		member, err := newVolumeGroupMember(&pvcs[i], pv, addedTime)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func newVolumeGroupMember(pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume,
+	addedTime metav1.Time) (volumegroupv2.VolumeGroupMember, error) {
+	member := volumegroupv2.VolumeGroupMember{
+		Name:                 pvc.Name,
+		Namespace:            pvc.Namespace,
+		UID:                  pvc.UID,
+		PersistentVolumeName: pvc.Spec.VolumeName,
+		AddedTime:            &addedTime,
+	}
+	if pv == nil {
+		return member, nil
+	}
+	volumeHandle, err := getVolumeHandle(pv)
+	if err != nil {
+		return volumegroupv2.VolumeGroupMember{}, err
+	}
+	member.VolumeHandle = volumeHandle
+	return member, nil
+}
+
+// SetVolumeGroupAnnotation saves the annotation on the volumeGroup, it is retried on conflicts because
+// the annotations hold the state of the group creation on the storage.
+func SetVolumeGroupAnnotation(ctx context.Context, client client.Client, logger logr.Logger, vg *volumegroupv2.VolumeGroup,
+	key, value string) error {
+	if vg.Annotations[key] == value {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		annotations := vg.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[key] = value
+		vg.SetAnnotations(annotations)
+		err := UpdateObject(ctx, client, vg)
+		if apierrors.IsConflict(err) {
+			if uErr := getNamespacedObject(ctx, client, vg); uErr != nil {
+				return uErr
+			}
+			logger.Info(fmt.Sprintf(messages.RetryUpdateVolumeGroupAnnotation, key, vg.Namespace, vg.Name))
+		}
+		return err
+	})
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// volumeGroupListRecorder fails every list of volumeGroups that is not served by an index or a namespace.
+type volumeGroupListRecorder struct {
+	client.Client
+	t *testing.T
+}
+
+func (c *volumeGroupListRecorder) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if _, ok := list.(*volumegroupv2.VolumeGroupList); ok {
+		listOpts := client.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		if listOpts.FieldSelector == nil && listOpts.Namespace == "" {
+			c.t.Errorf("volumeGroups were listed without an index or a namespace")
+		}
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestGetVGsAffectedByPVCListsThroughIndexes(t *testing.T) {
+	const driver = "driver.example.com"
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := volumegroupv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := volumegroupv2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	vgClassName := "volume-group-class"
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"group": "a"}}
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc", Namespace: "default",
+		Labels: map[string]string{"group": "a"}}}
+	newVG := func(name, namespace string, source volumegroupv2.VolumeGroupSource,
+		members []volumegroupv2.VolumeGroupMember) *volumegroupv2.VolumeGroup {
+		return &volumegroupv2.VolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       volumegroupv2.VolumeGroupSpec{VolumeGroupClassName: &vgClassName, Source: source},
+			Status:     volumegroupv2.VolumeGroupStatus{Members: members},
+		}
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&volumegroupv1.VolumeGroupClass{ObjectMeta: metav1.ObjectMeta{Name: vgClassName}, Driver: driver},
+		pvc,
+		newVG("selecting", "default", volumegroupv2.VolumeGroupSource{Selector: selector}, nil),
+		newVG("naming", "default", volumegroupv2.VolumeGroupSource{PersistentVolumeClaimNames: []string{"pvc"}}, nil),
+		newVG("member", "default", volumegroupv2.VolumeGroupSource{PersistentVolumeClaimNames: []string{"other"}},
+			[]volumegroupv2.VolumeGroupMember{{Name: "pvc", Namespace: "default"}}),
+		newVG("not-selecting", "default", volumegroupv2.VolumeGroupSource{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"group": "b"}}}, nil),
+		newVG("other-namespace", "other", volumegroupv2.VolumeGroupSource{Selector: selector}, nil),
+		newVG("naming-other-namespace", "other", volumegroupv2.VolumeGroupSource{PersistentVolumeClaimNames: []string{"pvc"}}, nil),
+	).Build()
+
+	vgs, err := GetVGsAffectedByPVC(context.Background(), logr.Discard(), &volumeGroupListRecorder{Client: k8sClient, t: t}, driver, pvc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vgNames := []string{}
+	for _, vg := range vgs {
+		vgNames = append(vgNames, vg.Namespace+"/"+vg.Name)
+	}
+	sort.Strings(vgNames)
+	expected := []string{"default/member", "default/naming", "default/selecting"}
+	if len(vgNames) != len(expected) {
+		t.Fatalf("expected volumeGroups %v, got %v", expected, vgNames)
+	}
+	for i := range expected {
+		if vgNames[i] != expected[i] {
+			t.Fatalf("expected volumeGroups %v, got %v", expected, vgNames)
+		}
+	}
+
+	if keys := indexVGPVCNames(newVG("naming", "default",
+		volumegroupv2.VolumeGroupSource{PersistentVolumeClaimNames: []string{"pvc"}}, nil)); len(keys) != 1 ||
+		keys[0] != getPVCIndexKey(pvc) {
+		t.Errorf("expected the volumeGroup to be indexed by %s, got %v", getPVCIndexKey(pvc), keys)
+	}
+}
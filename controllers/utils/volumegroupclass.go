@@ -18,25 +18,36 @@ package utils
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func getVGClassDriver(client client.Client, logger logr.Logger, vgcName string) (string, error) {
-	vgClass, err := GetVolumeGroupClass(client, logger, vgcName)
-	if err != nil {
-		return "", err
+func getVolumeGroupClassNamesOfDriver(ctx context.Context, client client.Client, logger logr.Logger, driver string) ([]string, error) {
+	vgClassList := &volumegroupv1.VolumeGroupClassList{}
+	if err := client.List(ctx, vgClassList); err != nil {
+		logger.Error(err, messages.FailedToListVolumeGroupClasses)
+		return nil, err
+	}
+	vgClassNames := []string{}
+	for _, vgClass := range vgClassList.Items {
+		if vgClass.Driver == driver {
+			vgClassNames = append(vgClassNames, vgClass.Name)
+		}
 	}
-	return vgClass.Driver, nil
+	return vgClassNames, nil
 }
 
-func GetVolumeGroupClass(client client.Client, logger logr.Logger, vgcName string) (*volumegroupv1.VolumeGroupClass, error) {
+func GetVolumeGroupClass(ctx context.Context, client client.Client, logger logr.Logger, vgcName string) (*volumegroupv1.VolumeGroupClass, error) {
 	vgcObj := &volumegroupv1.VolumeGroupClass{}
-	err := client.Get(context.TODO(), types.NamespacedName{Name: vgcName}, vgcObj)
+	err := client.Get(ctx, types.NamespacedName{Name: vgcName}, vgcObj)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error(err, "VolumeGroupClass not found", "VolumeGroupClass Name", vgcName)
@@ -48,3 +59,38 @@ func GetVolumeGroupClass(client client.Client, logger logr.Logger, vgcName strin
 	}
 	return vgcObj, nil
 }
+
+// GetDefaultVolumeGroupClass returns the only default volumeGroupClass of the drivers.
+func GetDefaultVolumeGroupClass(ctx context.Context, client client.Client, logger logr.Logger, drivers []string) (*volumegroupv1.VolumeGroupClass, error) {
+	driver := strings.Join(drivers, ", ")
+	logger.Info(fmt.Sprintf(messages.GetDefaultVolumeGroupClass, driver))
+	vgClassList := &volumegroupv1.VolumeGroupClassList{}
+	if err := client.List(ctx, vgClassList); err != nil {
+		logger.Error(err, messages.FailedToListVolumeGroupClasses)
+		return nil, err
+	}
+
+	defaultClasses := []volumegroupv1.VolumeGroupClass{}
+	for _, vgClass := range vgClassList.Items {
+		if Contains(drivers, vgClass.Driver) && IsDefaultVolumeGroupClass(&vgClass) {
+			defaultClasses = append(defaultClasses, vgClass)
+		}
+	}
+
+	switch len(defaultClasses) {
+	case 0:
+		return nil, &vgerrors.NoDefaultVolumeGroupClassError{Driver: driver}
+	case 1:
+		return &defaultClasses[0], nil
+	default:
+		names := []string{}
+		for _, vgClass := range defaultClasses {
+			names = append(names, vgClass.Name)
+		}
+		return nil, fmt.Errorf(messages.MultipleDefaultVolumeGroupClasses, driver, names)
+	}
+}
+
+func IsDefaultVolumeGroupClass(vgClass *volumegroupv1.VolumeGroupClass) bool {
+	return vgClass.Annotations[IsDefaultVolumeGroupClassAnnotation] == "true"
+}
@@ -5,7 +5,8 @@ import (
 	"fmt"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
-	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
 	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
 	"github.com/go-logr/logr"
@@ -18,29 +19,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func AddMatchingPVToMatchingVGC(logger logr.Logger, client client.Client,
-	pvc *corev1.PersistentVolumeClaim, vg *volumegroupv1.VolumeGroup) error {
-	pv, err := GetPVFromPVC(logger, client, pvc)
-	if err != nil {
-		return err
-	}
-	vgc, err := GetVolumeGroupContent(client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Name, vg.Namespace)
-	if err != nil {
-		return err
-	}
-
-	if pv != nil {
-		return addPVToVGC(logger, client, pv, vgc)
-	}
-	return nil
-}
-
-func GetVolumeGroupContent(client client.Client, logger logr.Logger,
-	volumeGroupContentName string, vgName string, vgNamespace string) (*volumegroupv1.VolumeGroupContent, error) {
+func GetVolumeGroupContent(ctx context.Context, client client.Client, logger logr.Logger,
+	volumeGroupContentName string, vgName string, vgNamespace string) (*volumegroupv2.VolumeGroupContent, error) {
 	logger.Info(fmt.Sprintf(messages.GetVolumeGroupContentOfVolumeGroup, vgName, vgNamespace))
-	vgc := &volumegroupv1.VolumeGroupContent{}
-	namespacedVGC := types.NamespacedName{Name: volumeGroupContentName, Namespace: vgNamespace}
-	err := client.Get(context.TODO(), namespacedVGC, vgc)
+	vgc := &volumegroupv2.VolumeGroupContent{}
+	err := client.Get(ctx, types.NamespacedName{Name: volumeGroupContentName}, vgc)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			logger.Error(err, "VolumeGroupContent not found", "VolumeGroupContent Name", volumeGroupContentName)
@@ -51,8 +34,8 @@ func GetVolumeGroupContent(client client.Client, logger logr.Logger,
 	return vgc, nil
 }
 
-func CreateVolumeGroupContent(client client.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent) error {
-	err := client.Create(context.TODO(), vgc)
+func CreateVolumeGroupContent(ctx context.Context, client client.Client, logger logr.Logger, vgc *volumegroupv2.VolumeGroupContent) error {
+	err := client.Create(ctx, vgc)
 	if err != nil {
 		if errors.IsAlreadyExists(err) {
 			logger.Info("VolumeGroupContent is already exists")
@@ -61,56 +44,124 @@ func CreateVolumeGroupContent(client client.Client, logger logr.Logger, vgc *vol
 		logger.Error(err, "VolumeGroupContent creation failed", "VolumeGroupContent Name")
 		return err
 	}
-	err = createSuccessVolumeGroupContentEvent(logger, client, vgc)
+	err = createSuccessVolumeGroupContentEvent(ctx, logger, client, vgc)
 	return err
 }
 
-func createSuccessVolumeGroupContentEvent(logger logr.Logger, client client.Client, vgc *volumegroupv1.VolumeGroupContent) error {
+func createSuccessVolumeGroupContentEvent(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv2.VolumeGroupContent) error {
 	vgc.APIVersion = APIVersion
 	vgc.Kind = volumeGroupContentKind
-	message := fmt.Sprintf(messages.VolumeGroupContentCreated, vgc.Namespace, vgc.Name)
-	err := createSuccessNamespacedObjectEvent(logger, client, vgc, message, createVGC)
+	message := fmt.Sprintf(messages.VolumeGroupContentCreated, vgc.Name)
+	err := createSuccessNamespacedObjectEvent(ctx, logger, client, vgc, message, createVGC)
 	if err != nil {
 		return nil
 	}
 	return nil
 }
 
-func UpdateVolumeGroupContentStatus(client client.Client, logger logr.Logger, vgc *volumegroupv1.VolumeGroupContent, groupCreationTime *metav1.Time, ready bool) error {
-	updateVolumeGroupContentStatusFields(vgc, groupCreationTime, ready)
-	if err := UpdateObjectStatus(client, vgc); err != nil {
+func UpdateVolumeGroupContentStatus(ctx context.Context, client client.Client, logger logr.Logger, vgc *volumegroupv2.VolumeGroupContent, groupCreationTime *metav1.Time) error {
+	updateVolumeGroupContentStatusFields(vgc, groupCreationTime)
+	if err := UpdateObjectStatus(ctx, client, vgc); err != nil {
 		logger.Error(err, "failed to update status")
 		return err
 	}
 	return nil
 }
 
-func updateVolumeGroupContentStatusFields(vgc *volumegroupv1.VolumeGroupContent, groupCreationTime *metav1.Time, ready bool) {
+func updateVolumeGroupContentStatusFields(vgc *volumegroupv2.VolumeGroupContent, groupCreationTime *metav1.Time) {
 	vgc.Status.GroupCreationTime = groupCreationTime
-	vgc.Status.Ready = &ready
+	vgc.Status.ObservedGeneration = vgc.Generation
+	vgc.Status.Phase = volumegroupv2.VolumeGroupContentBound
+	message := ""
+	if vgc.Spec.VolumeGroupRef != nil {
+		message = fmt.Sprintf(messages.VolumeGroupContentBound, vgc.Spec.VolumeGroupRef.Namespace, vgc.Spec.VolumeGroupRef.Name)
+	}
+	setBoundCondition(&vgc.Status.Conditions, vgc.Generation, message)
+	setReconciledCondition(&vgc.Status.Conditions, vgc.Generation)
+}
+
+func UpdateVolumeGroupContentStatusCondition(ctx context.Context, client client.Client, vgc *volumegroupv2.VolumeGroupContent, logger logr.Logger,
+	vgcErr error, reason string) error {
+	message := GetMessageFromError(vgcErr)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vgc.Status.ObservedGeneration = vgc.Generation
+		if reason == deleteVG {
+			vgc.Status.Phase = volumegroupv2.VolumeGroupContentFailed
+		}
+		setFailedCondition(&vgc.Status.Conditions, vgc.Generation, vgcErr, reason, message)
+		err := vgcRetryOnConflictFunc(ctx, client, vgc, logger)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func GenerateVolumeGroupContent(vgname string, instance *volumegroupv1.VolumeGroup, vgClass *volumegroupv1.VolumeGroupClass, resp *volumegroup.Response, secretName string, secretNamespace string) *volumegroupv1.VolumeGroupContent {
-	return &volumegroupv1.VolumeGroupContent{
+func GenerateVolumeGroupContent(vgname string, instance *volumegroupv2.VolumeGroup, vgClass *volumegroupv1.VolumeGroupClass, storageVG *csi.VolumeGroup, secretName string, secretNamespace string) *volumegroupv2.VolumeGroupContent {
+	return &volumegroupv2.VolumeGroupContent{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      vgname,
-			Namespace: instance.Namespace,
+			Name: vgname,
 		},
-		Spec: generateVolumeGroupContentSpec(instance, vgClass, resp, secretName, secretNamespace),
+		Spec: generateVolumeGroupContentSpec(instance, vgClass, storageVG, secretName, secretNamespace),
+	}
+}
+
+func generateVolumeGroupContentSpec(instance *volumegroupv2.VolumeGroup, vgClass *volumegroupv1.VolumeGroupClass,
+	storageVG *csi.VolumeGroup, secretName string, secretNamespace string) volumegroupv2.VolumeGroupContentSpec {
+	return volumegroupv2.VolumeGroupContentSpec{
+		VolumeGroupClassName:      instance.Spec.VolumeGroupClassName,
+		VolumeGroupRef:            generateObjectReference(instance),
+		Source:                    generateVolumeGroupContentSource(vgClass, storageVG),
+		VolumeGroupDeletionPolicy: getVolumeGroupDeletionPolicy(vgClass),
+		VolumeGroupSecretRef:      generateSecretReference(secretName, secretNamespace),
 	}
 }
 
-func generateVolumeGroupContentSpec(instance *volumegroupv1.VolumeGroup, vgClass *volumegroupv1.VolumeGroupClass,
-	resp *volumegroup.Response, secretName string, secretNamespace string) volumegroupv1.VolumeGroupContentSpec {
-	return volumegroupv1.VolumeGroupContentSpec{
-		VolumeGroupClassName: instance.Spec.VolumeGroupClassName,
-		VolumeGroupRef:       generateObjectReference(instance),
-		Source:               generateVolumeGroupContentSource(vgClass, resp),
-		VolumeGroupSecretRef: generateSecretReference(secretName, secretNamespace),
+func getVolumeGroupDeletionPolicy(vgClass *volumegroupv1.VolumeGroupClass) *volumegroupv2.VolumeGroupDeletionPolicy {
+	deletionPolicy := volumegroupv2.VolumeGroupContentDelete
+	if vgClass.VolumeGroupDeletionPolicy != nil {
+		deletionPolicy = volumegroupv2.VolumeGroupDeletionPolicy(*vgClass.VolumeGroupDeletionPolicy)
+	}
+	return &deletionPolicy
+}
+
+func IsVolumeGroupContentRetained(vgc *volumegroupv2.VolumeGroupContent) bool {
+	return vgc.Spec.VolumeGroupDeletionPolicy != nil &&
+		*vgc.Spec.VolumeGroupDeletionPolicy == volumegroupv2.VolumeGroupContentRetain
+}
+
+// ReleaseVolumeGroupContent unbinds a retained volumeGroupContent from its deleted volumeGroup.
+// The reference keeps the namespace and name, so a volumeGroup with the same namespace and name can
+// bind it again, clearing the reference makes the content available to any volumeGroup.
+func ReleaseVolumeGroupContent(ctx context.Context, client client.Client, logger logr.Logger, vgc *volumegroupv2.VolumeGroupContent) error {
+	logger.Info(fmt.Sprintf(messages.RetainVolumeGroupContent, vgc.Name))
+	message := ""
+	if vgc.Spec.VolumeGroupRef != nil {
+		message = fmt.Sprintf(messages.VolumeGroupContentReleased, vgc.Spec.VolumeGroupRef.Namespace, vgc.Spec.VolumeGroupRef.Name)
+	}
+	if vgc.Spec.VolumeGroupRef != nil {
+		vgc.Spec.VolumeGroupRef.UID = ""
+		vgc.Spec.VolumeGroupRef.ResourceVersion = ""
+	}
+	if err := UpdateObject(ctx, client, vgc); err != nil {
+		logger.Error(err, "failed to release volumeGroupContent", "VGCName", vgc.Name)
+		return err
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vgc.Status.ObservedGeneration = vgc.Generation
+		vgc.Status.Phase = volumegroupv2.VolumeGroupContentReleased
+		setReleasedCondition(&vgc.Status.Conditions, vgc.Generation, message)
+		return vgcRetryOnConflictFunc(ctx, client, vgc, logger)
+	})
+	if err != nil {
+		return err
 	}
+	return RemoveFinalizerFromVGC(ctx, client, logger, vgc)
 }
 
-func generateObjectReference(instance *volumegroupv1.VolumeGroup) *corev1.ObjectReference {
+func generateObjectReference(instance *volumegroupv2.VolumeGroup) *corev1.ObjectReference {
 	return &corev1.ObjectReference{
 		Kind:            instance.Kind,
 		Namespace:       instance.Namespace,
@@ -128,73 +179,31 @@ func generateSecretReference(secretName string, secretNamespace string) *corev1.
 	}
 }
 
-func generateVolumeGroupContentSource(vgClass *volumegroupv1.VolumeGroupClass, resp *volumegroup.Response) *volumegroupv1.VolumeGroupContentSource {
-	CreateVolumeGroupResponse := resp.Response.(*csi.CreateVolumeGroupResponse)
-	return &volumegroupv1.VolumeGroupContentSource{
+func generateVolumeGroupContentSource(vgClass *volumegroupv1.VolumeGroupClass, storageVG *csi.VolumeGroup) *volumegroupv2.VolumeGroupContentSource {
+	return &volumegroupv2.VolumeGroupContentSource{
 		Driver:                vgClass.Driver,
-		VolumeGroupHandle:     CreateVolumeGroupResponse.VolumeGroup.VolumeGroupId,
-		VolumeGroupAttributes: CreateVolumeGroupResponse.VolumeGroup.VolumeGroupContext,
+		VolumeGroupHandle:     storageVG.GetVolumeGroupId(),
+		VolumeGroupAttributes: storageVG.GetVolumeGroupContext(),
 	}
 }
 
-func RemovePVFromVGC(logger logr.Logger, client client.Client, pv *corev1.PersistentVolume, vgc *volumegroupv1.VolumeGroupContent) error {
-	logger.Info(fmt.Sprintf(messages.RemovePersistentVolumeFromVolumeGroupContent,
-		pv.Namespace, pv.Name, vgc.Namespace, vgc.Name))
-	vgc.Status.PVList = removeFromPVList(pv, vgc.Status.PVList)
-	err := updateVolumeGroupContentStatusPVList(client, vgc, logger, vgc.Status.PVList)
+func updateVolumeGroupContentMembers(ctx context.Context, logger logr.Logger, client client.Client, vgc *volumegroupv2.VolumeGroupContent,
+	members []volumegroupv2.VolumeGroupMember) error {
+	previousMembers := vgc.Status.Members
+	err := updateVolumeGroupContentStatusMembers(ctx, client, vgc, logger, members)
 	if err != nil {
-		vgc.Status.PVList = appendPersistentVolume(vgc.Status.PVList, *pv)
-		logger.Error(err, fmt.Sprintf(messages.FailedToRemovePersistentVolumeFromVolumeGroupContent,
-			pv.Name, vgc.Namespace, vgc.Name))
+		vgc.Status.Members = previousMembers
+		logger.Error(err, fmt.Sprintf(messages.FailedToUpdatePersistentVolumesOfVolumeGroupContent, vgc.Name))
 		return err
 	}
-	logger.Info(fmt.Sprintf(messages.RemovedPersistentVolumeFromVolumeGroupContent,
-		pv.Name, vgc.Namespace, vgc.Name))
 	return nil
 }
 
-func removeFromPVList(pv *corev1.PersistentVolume, pvList []corev1.PersistentVolume) []corev1.PersistentVolume {
-	for index, pvFromList := range pvList {
-		if pvFromList.Name == pv.Name && pvFromList.Namespace == pv.Namespace {
-			pvList = removeByIndexFromPersistentVolumeList(pvList, index)
-			return pvList
-		}
-	}
-	return pvList
-}
-
-func addPVToVGC(logger logr.Logger, client client.Client, pv *corev1.PersistentVolume,
-	vgc *volumegroupv1.VolumeGroupContent) error {
-	logger.Info(fmt.Sprintf(messages.AddPersistentVolumeToVolumeGroupContent,
-		pv.Name, vgc.Namespace, vgc.Name))
-	vgc.Status.PVList = appendPersistentVolume(vgc.Status.PVList, *pv)
-	err := updateVolumeGroupContentStatusPVList(client, vgc, logger, vgc.Status.PVList)
-	if err != nil {
-		vgc.Status.PVList = removeFromPVList(pv, vgc.Status.PVList)
-		logger.Error(err, fmt.Sprintf(messages.FailedToAddPersistentVolumeToVolumeGroupContent,
-			pv.Name, vgc.Namespace, vgc.Name))
-		return err
-	}
-	logger.Info(fmt.Sprintf(messages.AddedPersistentVolumeToVolumeGroupContent,
-		pv.Name, vgc.Namespace, vgc.Name))
-	return nil
-}
-
-func appendPersistentVolume(pvListInVGC []corev1.PersistentVolume, pv corev1.PersistentVolume) []corev1.PersistentVolume {
-	for _, pvFromList := range pvListInVGC {
-		if pvFromList.Name == pv.Name {
-			return pvListInVGC
-		}
-	}
-	pvListInVGC = append(pvListInVGC, pv)
-	return pvListInVGC
-}
-
-func updateVolumeGroupContentStatusPVList(client client.Client, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger,
-	pvList []corev1.PersistentVolume) error {
+func updateVolumeGroupContentStatusMembers(ctx context.Context, client client.Client, vgc *volumegroupv2.VolumeGroupContent, logger logr.Logger,
+	members []volumegroupv2.VolumeGroupMember) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		vgc.Status.PVList = pvList
-		err := vgcRetryOnConflictFunc(client, vgc, logger)
+		vgc.Status.Members = members
+		err := vgcRetryOnConflictFunc(ctx, client, vgc, logger)
 		return err
 	})
 	if err != nil {
@@ -204,35 +213,96 @@ func updateVolumeGroupContentStatusPVList(client client.Client, vgc *volumegroup
 	return nil
 }
 
-func vgcRetryOnConflictFunc(client client.Client, vgc *volumegroupv1.VolumeGroupContent, logger logr.Logger) error {
-	err := UpdateObjectStatus(client, vgc)
+func vgcRetryOnConflictFunc(ctx context.Context, client client.Client, vgc *volumegroupv2.VolumeGroupContent, logger logr.Logger) error {
+	err := UpdateObjectStatus(ctx, client, vgc)
 	if apierrors.IsConflict(err) {
-		uErr := getNamespacedObject(client, vgc)
+		uErr := getNamespacedObject(ctx, client, vgc)
 		if uErr != nil {
 			return uErr
 		}
-		logger.Info(fmt.Sprintf(messages.RetryUpdateVolumeGroupStatus, vgc.Namespace, vgc.Name))
+		logger.Info(fmt.Sprintf(messages.RetryUpdateVolumeGroupContentStatus, vgc.Name))
 	}
 	return err
 }
 
-func UpdateStaticVGC(client client.Client, vg *volumegroupv1.VolumeGroup,
+func UpdateStaticVGC(ctx context.Context, client client.Client, vg *volumegroupv2.VolumeGroup,
 	vgClass *volumegroupv1.VolumeGroupClass, logger logr.Logger) error {
-	vgc, err := GetVolumeGroupContent(client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Name, vg.Namespace)
+	vgc, err := GetVolumeGroupContent(ctx, client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Name, vg.Namespace)
 	if err != nil {
 		return err
 	}
+	if err = validateVolumeGroupContentBinding(vgc, vg); err != nil {
+		return err
+	}
 	updateStaticVGCSpec(vgClass, vgc, vg)
-	if err = UpdateObject(client, vgc); err != nil {
+	if err = UpdateObject(ctx, client, vgc); err != nil {
 		return err
 	}
 	return nil
 }
 
-func updateStaticVGCSpec(vgClass *volumegroupv1.VolumeGroupClass, vgc *volumegroupv1.VolumeGroupContent, vg *volumegroupv1.VolumeGroup) {
+func updateStaticVGCSpec(vgClass *volumegroupv1.VolumeGroupClass, vgc *volumegroupv2.VolumeGroupContent, vg *volumegroupv2.VolumeGroup) {
 	secretName, secretNamespace := GetSecretCred(vgClass)
 	vgc.Spec.VolumeGroupClassName = vg.Spec.VolumeGroupClassName
 	vgc.Spec.VolumeGroupRef = generateObjectReference(vg)
 	vgc.Spec.Source.Driver = vgClass.Driver
 	vgc.Spec.VolumeGroupSecretRef = generateSecretReference(secretName, secretNamespace)
+	if vgc.Spec.VolumeGroupDeletionPolicy == nil {
+		vgc.Spec.VolumeGroupDeletionPolicy = getVolumeGroupDeletionPolicy(vgClass)
+	}
+}
+
+func ValidateStaticVolumeGroupContentBinding(ctx context.Context, client client.Client, logger logr.Logger, vg *volumegroupv2.VolumeGroup) error {
+	vgc, err := GetVolumeGroupContent(ctx, client, logger, *vg.Spec.Source.VolumeGroupContentName, vg.Name, vg.Namespace)
+	if err != nil {
+		return err
+	}
+	return validateVolumeGroupContentBinding(vgc, vg)
+}
+
+// IsVolumeGroupContentBoundToOtherVG checks the volumeGroupContent side of the binding, a content that
+// refers to a volumeGroup can only be bound to that volumeGroup. A content without a volumeGroup UID is
+// reserved for the volumeGroup with the referred namespace and name.
+func IsVolumeGroupContentBoundToOtherVG(vgc *volumegroupv2.VolumeGroupContent, vg *volumegroupv2.VolumeGroup) bool {
+	vgRef := vgc.Spec.VolumeGroupRef
+	if vgRef == nil {
+		return false
+	}
+	if vgRef.Name != vg.Name || vgRef.Namespace != vg.Namespace {
+		return true
+	}
+	return vgRef.UID != "" && vgRef.UID != vg.UID
+}
+
+func validateVolumeGroupContentBinding(vgc *volumegroupv2.VolumeGroupContent, vg *volumegroupv2.VolumeGroup) error {
+	if !IsVolumeGroupContentBoundToOtherVG(vgc, vg) {
+		return nil
+	}
+	vgRef := vgc.Spec.VolumeGroupRef
+	return &vgerrors.VolumeGroupContentBoundToOtherVolumeGroupError{
+		VGCName:     vgc.Name,
+		VGName:      vgRef.Name,
+		VGNamespace: vgRef.Namespace,
+		Reserved:    vgRef.UID == "",
+	}
+}
+
+func UpdateVolumeGroupContentStatusPhase(ctx context.Context, client client.Client, logger logr.Logger, vgc *volumegroupv2.VolumeGroupContent,
+	phase volumegroupv2.VolumeGroupContentPhase, message string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vgc.Status.ObservedGeneration = vgc.Generation
+		vgc.Status.Phase = phase
+		switch phase {
+		case volumegroupv2.VolumeGroupContentAvailable:
+			setCondition(&vgc.Status.Conditions, vgc.Generation, volumegroupv2.ConditionBound, metav1.ConditionFalse,
+				bindingAvailable, message)
+		case volumegroupv2.VolumeGroupContentReleased:
+			setReleasedCondition(&vgc.Status.Conditions, vgc.Generation, message)
+		}
+		return vgcRetryOnConflictFunc(ctx, client, vgc, logger)
+	})
+	if err != nil {
+		return err
+	}
+	return nil
 }
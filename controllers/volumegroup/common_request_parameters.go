@@ -8,5 +8,7 @@ type CommonRequestParameters struct {
 	VolumeIds     []string
 	Parameters    map[string]string
 	Secrets       map[string]string
+	MaxEntries    int32
+	StartingToken string
 	VolumeGroup   client.VolumeGroup
 }
@@ -18,13 +18,18 @@ package controllers
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"time"
 
 	"github.com/IBM/csi-volume-group-operator/controllers/utils"
 	"github.com/IBM/csi-volume-group-operator/controllers/volumegroup"
 	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"github.com/IBM/csi-volume-group-operator/pkg/tracing"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -34,11 +39,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -49,11 +60,14 @@ const (
 
 type VolumeGroupReconciler struct {
 	client.Client
-	Log               logr.Logger
-	Scheme            *runtime.Scheme
-	DriverConfig      *config.DriverConfig
-	GRPCClient        *grpcClient.Client
-	VolumeGroupClient grpcClient.VolumeGroup
+	Log          logr.Logger
+	Scheme       *runtime.Scheme
+	DriverConfig *config.DriverConfig
+	// GRPCClients and VolumeGroupClients hold the clients of every served driver by driver name.
+	GRPCClients        map[string]*grpcClient.Client
+	VolumeGroupClients map[string]grpcClient.VolumeGroup
+	// APIReader reads the volumeGroup before its membership is changed, bypassing the cache.
+	APIReader client.Reader
 }
 
 //+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroups,verbs=get;list;watch;create;update;patch;delete
@@ -61,7 +75,7 @@ type VolumeGroupReconciler struct {
 //+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroups/finalizers,verbs=update
 //+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupcontents,verbs=get;list;watch
-//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=persistentvolumeclaims/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups="",resources=persistentvolumeclaims/finalizers,verbs=update
 
@@ -69,117 +83,246 @@ func (r *VolumeGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	logger := r.Log.WithValues("Request.Name", req.Name, "Request.Namespace", req.Namespace)
 	logger.Info(messages.ReconcileVolumeGroup)
 
-	instance := &volumegroupv1.VolumeGroup{}
-	if err := r.Client.Get(context.TODO(), req.NamespacedName, instance); err != nil {
+	ctx, span := tracing.StartReconcileSpan(ctx, VolumeGroup, req)
+	result, err := r.reconcileVolumeGroup(ctx, logger, req)
+	tracing.EndSpan(span, err)
+	metrics.RecordReconcileOutcome(VolumeGroup, result, err)
+	return result, utils.IgnoreTerminalDriverError(logger, err)
+}
+
+func (r *VolumeGroupReconciler) reconcileVolumeGroup(ctx context.Context, logger logr.Logger, req ctrl.Request) (ctrl.Result, error) {
+	instance := &volumegroupv2.VolumeGroup{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
 		if errors.IsNotFound(err) {
 
 			logger.Info("VolumeGroup resource not found")
 
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, vgReconcile)
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, vgReconcile)
 	}
 
-	vgClass, err := utils.GetVolumeGroupClass(r.Client, logger, *instance.Spec.VolumeGroupClassName)
+	if instance.Spec.VolumeGroupClassName == nil || *instance.Spec.VolumeGroupClassName == "" {
+		if !instance.GetDeletionTimestamp().IsZero() {
+			return ctrl.Result{}, nil
+		}
+		if err := utils.SetDefaultVolumeGroupClass(ctx, r.Client, logger, instance, r.DriverConfig.GetDriverNames()); err != nil {
+			var noDefaultErr *vgerrors.NoDefaultVolumeGroupClassError
+			if goerrors.As(err, &noDefaultErr) {
+				logger.Info(err.Error())
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, vgReconcile)
+		}
+	}
+
+	vgClass, err := utils.GetVolumeGroupClass(ctx, r.Client, logger, *instance.Spec.VolumeGroupClassName)
 	if err != nil {
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, vgReconcile)
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, vgReconcile)
 	}
 
-	if r.DriverConfig.DriverName != vgClass.Driver {
+	driver := vgClass.Driver
+	if !r.DriverConfig.IsDriverServed(driver) {
 		return ctrl.Result{}, nil
 	}
+	if utils.IsDriverDisconnected(logger, r.GRPCClients[driver]) {
+		return ctrl.Result{RequeueAfter: utils.DriverDisconnectedRequeueInterval}, nil
+	}
 
 	if err = utils.ValidatePrefixedParameters(vgClass.Parameters); err != nil {
 		logger.Error(err, "failed to validate parameters of volumegroupClass", "VGClassName", vgClass.Name)
-		if uErr := utils.UpdateVolumeGroupStatusError(r.Client, instance, logger, err.Error()); uErr != nil {
+		if uErr := utils.UpdateVolumeGroupStatusCondition(ctx, r.Client, instance, logger, err, invalidParams); uErr != nil {
 			return ctrl.Result{}, uErr
 		}
 		return ctrl.Result{}, err
 	}
 	parameters := utils.FilterPrefixedParameters(utils.VolumeGroupAsPrefix, vgClass.Parameters)
 
-	secret, err := utils.GetSecretDataFromClass(r.Client, vgClass, logger, instance)
+	secret, err := utils.GetSecretDataFromClass(ctx, r.Client, vgClass, logger, instance)
 	if err != nil {
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, vgReconcile)
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, vgReconcile)
 	}
 
 	if instance.GetDeletionTimestamp().IsZero() {
-		if err = utils.AddFinalizerToVG(r.Client, logger, instance); err != nil {
-			return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, createVG)
+		if err = utils.AddFinalizerToVG(ctx, r.Client, logger, instance); err != nil {
+			return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, createVG)
 		}
 
 	} else {
 		if utils.Contains(instance.GetFinalizers(), utils.VolumeGroupFinalizer) {
-			if err = r.removeInstance(logger, instance, secret); err != nil {
-				return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, deleteVG)
+			if r.isPVCDeletionEnabled(vgClass) {
+				if err = r.removeStorageVolumeGroupMembers(ctx, logger, instance, driver, secret); err != nil {
+					return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, deletePVCs)
+				}
+				remainingPVCs, err := utils.DeletePersistentVolumeClaimsOfVG(ctx, logger, r.Client, driver, instance)
+				if err != nil {
+					return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, deletePVCs)
+				}
+				if remainingPVCs > 0 {
+					return ctrl.Result{RequeueAfter: pvcDeletionRequeueInterval},
+						utils.UpdateVolumeGroupDeletionProgress(ctx, r.Client, instance, logger, remainingPVCs)
+				}
 			}
-			if r.DriverConfig.DisableDeletePvcs == "false" {
-				//TODO CSI-5167 Delete all VG's PVCs
+			if err = r.removeInstance(ctx, logger, instance, vgClass, secret); err != nil {
+				return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, deleteVG)
 			}
 		}
 		logger.Info("volumeGroup object is terminated, skipping reconciliation")
 		return ctrl.Result{}, nil
 	}
 
+	// The membership is computed from the latest volumeGroup and sent to the driver while the
+	// persistentVolumeClaim controller cannot change it.
+	unlock := utils.LockVolumeGroupMembership(instance)
+	defer unlock()
+	if err = utils.RefreshVolumeGroup(ctx, r.APIReader, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
 	groupCreationTime := getCurrentTime()
 
-	err, isStaticProvisioned := r.handleStaticProvisionedVG(instance, err, logger, groupCreationTime, vgClass)
+	err, isStaticProvisioned := r.handleStaticProvisionedVG(ctx, instance, err, logger, groupCreationTime, vgClass)
 	if isStaticProvisioned {
-		return ctrl.Result{}, err
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.resyncMembership(ctx, logger, instance, vgClass)
 	}
 
 	volumeGroupName, err := makeVolumeGroupName(utils.VolumeGroupNamePrefix, string(instance.UID))
 	if err != nil {
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, createVG)
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, createVG)
 	}
 
-	createVolumeGroupResponse := r.createVolumeGroup(volumeGroupName, parameters, secret)
-	if createVolumeGroupResponse.Error != nil {
-		logger.Error(createVolumeGroupResponse.Error, "failed to create volume group")
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, createVolumeGroupResponse.Error, createVG)
+	initialPVCs, err := r.getPVCsToAddToVG(ctx, logger, instance, driver)
+	if err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, addingPVC)
+	}
+
+	storageVG, err := r.getOrCreateStorageVolumeGroup(ctx, logger, instance, driver, volumeGroupName, parameters, secret)
+	if err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, createVG)
+	}
+	// CreateVolumeGroup has no volume IDs in the CSI volume group API, the initial members are added
+	// before the volumeGroupContent is created, so the group is not published without them.
+	err = utils.AddVolumesToNewVolumeGroup(ctx, logger, r.Client, r.VolumeGroupClients[driver], storageVG.GetVolumeGroupId(), initialPVCs, secret)
+	if err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, addingPVC)
 	}
 	secretName, secretNamespace := utils.GetSecretCred(vgClass)
-	vgc := utils.GenerateVolumeGroupContent(volumeGroupName, instance, vgClass, createVolumeGroupResponse, secretName, secretNamespace)
+	vgc := utils.GenerateVolumeGroupContent(volumeGroupName, instance, vgClass, storageVG, secretName, secretNamespace)
 	logger.Info("GenerateVolumeGroupContent", "vgc", vgc)
-	if err = utils.CreateVolumeGroupContent(r.Client, logger, vgc); err != nil {
-		return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, createVGC)
+	if err = utils.CreateVolumeGroupContent(ctx, r.Client, logger, vgc); err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, createVGC)
 	}
 
-	err = r.updateItems(instance, logger, groupCreationTime, volumeGroupName)
+	err = r.updateItems(ctx, instance, logger, groupCreationTime, volumeGroupName)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	err = r.updatePVCs(err, logger, instance)
+	if err = utils.AddVolumesToPvcListAndPvList(ctx, logger, r.Client, initialPVCs, instance); err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, addingPVC)
+	}
+
+	err = r.updatePVCs(ctx, err, logger, instance, driver)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	r.createSuccessVolumeGroupEvent(logger, instance)
-	return ctrl.Result{}, utils.HandleErrorMessage(logger, r.Client, instance, err, vgReconcile)
+	r.createSuccessVolumeGroupEvent(ctx, logger, instance)
+	return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, vgReconcile)
 }
 
-func (r *VolumeGroupReconciler) updatePVCs(err error, logger logr.Logger, instance *volumegroupv1.VolumeGroup) error {
-	if err = r.removeVolumesFromVG(logger, instance); err != nil {
-		return utils.HandleErrorMessage(logger, r.Client, instance, err, removingPVC)
+// getOrCreateStorageVolumeGroup creates the group on the storage once. The intended name is saved on
+// the volumeGroup before CreateVolumeGroup and the returned handle right after it, so a reconcile that
+// failed later on continues with the saved group instead of creating it again. When the operator stopped
+// between the two, the group is looked up on the storage by the saved name.
+func (r *VolumeGroupReconciler) getOrCreateStorageVolumeGroup(ctx context.Context, logger logr.Logger, instance *volumegroupv2.VolumeGroup,
+	driver, volumeGroupName string, parameters, secret map[string]string) (*csi.VolumeGroup, error) {
+	if volumeGroupId := instance.Annotations[utils.VolumeGroupHandleAnnotation]; volumeGroupId != "" {
+		logger.Info(fmt.Sprintf(messages.ResumeVolumeGroupCreation, instance.Namespace, instance.Name, volumeGroupId))
+		return utils.GetStorageVolumeGroup(ctx, logger, r.VolumeGroupClients[driver], volumeGroupId, secret)
 	}
-	if err = r.addMatchingVolumesToVG(logger, instance); err != nil {
-		return utils.HandleErrorMessage(logger, r.Client, instance, err, addingPVC)
+
+	if savedName := instance.Annotations[utils.VolumeGroupNameAnnotation]; savedName != "" {
+		volumeGroupName = savedName
+		storageVG, err := utils.FindStorageVolumeGroupByName(ctx, logger, r.VolumeGroupClients[driver], volumeGroupName, secret)
+		if err != nil {
+			return nil, err
+		}
+		if storageVG != nil {
+			logger.Info(fmt.Sprintf(messages.ResumeVolumeGroupCreationByName, instance.Namespace, instance.Name,
+				storageVG.GetVolumeGroupId(), volumeGroupName))
+			return storageVG, r.saveVolumeGroupHandle(ctx, logger, instance, storageVG)
+		}
+	} else {
+		logger.Info(fmt.Sprintf(messages.SaveVolumeGroupName, volumeGroupName, instance.Namespace, instance.Name))
+		if err := utils.SetVolumeGroupAnnotation(ctx, r.Client, logger, instance, utils.VolumeGroupNameAnnotation, volumeGroupName); err != nil {
+			return nil, err
+		}
+	}
+	createVolumeGroupResponse := r.createVolumeGroup(ctx, driver, volumeGroupName, parameters, secret)
+	if createVolumeGroupResponse.Error != nil {
+		logger.Error(createVolumeGroupResponse.Error, "failed to create volume group")
+		return nil, createVolumeGroupResponse.Error
+	}
+	storageVG := createVolumeGroupResponse.Response.(*csi.CreateVolumeGroupResponse).GetVolumeGroup()
+	return storageVG, r.saveVolumeGroupHandle(ctx, logger, instance, storageVG)
+}
+
+func (r *VolumeGroupReconciler) saveVolumeGroupHandle(ctx context.Context, logger logr.Logger, instance *volumegroupv2.VolumeGroup,
+	storageVG *csi.VolumeGroup) error {
+	logger.Info(fmt.Sprintf(messages.SaveVolumeGroupHandle, storageVG.GetVolumeGroupId(), instance.Namespace, instance.Name))
+	return utils.SetVolumeGroupAnnotation(ctx, r.Client, logger, instance, utils.VolumeGroupHandleAnnotation,
+		storageVG.GetVolumeGroupId())
+}
+
+// updatePVCs adds the matching persistentVolumeClaims to the group and removes the unmatched ones
+// with a single request to the driver.
+func (r *VolumeGroupReconciler) updatePVCs(ctx context.Context, err error, logger logr.Logger, instance *volumegroupv2.VolumeGroup,
+	driver string) error {
+	pvcsToRemove, err := r.getPVCsToRemoveFromVG(ctx, logger, instance)
+	if err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, removingPVC)
+	}
+	pvcsToAdd, err := r.getPVCsToAddToVG(ctx, logger, instance, driver)
+	if err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, addingPVC)
+	}
+	if len(pvcsToAdd) == 0 && len(pvcsToRemove) == 0 {
+		return nil
+	}
+	err = utils.ModifyVolumeGroupMembership(ctx, logger, r.Client, r.VolumeGroupClients[driver], pvcsToAdd, pvcsToRemove, instance)
+	if err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, modifyingPVCs)
+	}
+	if err = utils.RemoveVolumesFromPvcListAndPvList(ctx, logger, r.Client, driver, pvcsToRemove, instance); err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, removingPVC)
+	}
+	if err = utils.AddVolumesToPvcListAndPvList(ctx, logger, r.Client, pvcsToAdd, instance); err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, addingPVC)
 	}
 	return nil
 }
 
-func (r *VolumeGroupReconciler) handleStaticProvisionedVG(instance *volumegroupv1.VolumeGroup, err error, logger logr.Logger, groupCreationTime *metav1.Time, vgClass *volumegroupv1.VolumeGroupClass) (error, bool) {
+func (r *VolumeGroupReconciler) handleStaticProvisionedVG(ctx context.Context, instance *volumegroupv2.VolumeGroup, err error, logger logr.Logger, groupCreationTime *metav1.Time, vgClass *volumegroupv1.VolumeGroupClass) (error, bool) {
 	if instance.Spec.Source.VolumeGroupContentName != nil {
-		err = r.updateItems(instance, logger, groupCreationTime, *instance.Spec.Source.VolumeGroupContentName)
+		if err = utils.ValidateStaticVolumeGroupContentBinding(ctx, r.Client, logger, instance); err != nil {
+			return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, vgReconcile), true
+		}
+		err = r.updateItems(ctx, instance, logger, groupCreationTime, *instance.Spec.Source.VolumeGroupContentName)
 		if err != nil {
 			return err, true
 		}
-		err = utils.UpdateStaticVGC(r.Client, instance, vgClass, logger)
+		err = utils.UpdateStaticVGC(ctx, r.Client, instance, vgClass, logger)
 		if err != nil {
 			return err, true
 		}
-		err = r.updatePVCs(err, logger, instance)
+		err = r.updatePVCs(ctx, err, logger, instance, vgClass.Driver)
 		if err != nil {
 			return err, true
 		}
@@ -188,63 +331,169 @@ func (r *VolumeGroupReconciler) handleStaticProvisionedVG(instance *volumegroupv
 	return nil, false
 }
 
-func (r *VolumeGroupReconciler) updateItems(instance *volumegroupv1.VolumeGroup, logger logr.Logger, groupCreationTime *metav1.Time, vgcName string) error {
-	vgc, err := utils.GetVolumeGroupContent(r.Client, logger, vgcName, instance.Name, instance.Namespace)
+// resyncMembership compares the membership of a bound volumeGroup with the group on the storage
+// and requeues the volumeGroup for the next comparison.
+func (r *VolumeGroupReconciler) resyncMembership(ctx context.Context, logger logr.Logger, instance *volumegroupv2.VolumeGroup,
+	vgClass *volumegroupv1.VolumeGroupClass) (ctrl.Result, error) {
+	if r.DriverConfig.ResyncPeriod <= 0 {
+		return ctrl.Result{}, nil
+	}
+	result := ctrl.Result{RequeueAfter: r.DriverConfig.ResyncPeriod}
+	err := utils.CheckVolumeGroupMembershipDrift(ctx, logger, r.Client, instance, r.VolumeGroupClients[vgClass.Driver])
+	var driftErr *vgerrors.VolumeGroupMembershipDriftError
+	if err != nil && !goerrors.As(err, &driftErr) {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, membershipDrift)
+	}
+	if driftErr == nil {
+		message := fmt.Sprintf(messages.VolumeGroupMembershipInSync, instance.Namespace, instance.Name)
+		return result, utils.UpdateVolumeGroupMembershipInSync(ctx, logger, r.Client, instance, message)
+	}
+
+	logger.Info(driftErr.Error())
+	if !utils.IsMembershipDriftRepairEnabled(vgClass) {
+		// the drift is reported and checked again on the next resync, only failing to report it is retried
+		if err = utils.HandleErrorMessage(ctx, logger, r.Client, instance, driftErr, membershipDrift); err != driftErr {
+			return ctrl.Result{}, err
+		}
+		return result, nil
+	}
+	if err = utils.ModifyVolumeGroup(ctx, logger, r.Client, instance, r.VolumeGroupClients[vgClass.Driver]); err != nil {
+		return ctrl.Result{}, utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, membershipDrift)
+	}
+	message := fmt.Sprintf(messages.VolumeGroupMembershipRepaired, driftErr.VolumeGroupID, instance.Namespace, instance.Name)
+	return result, utils.HandleSuccessMessage(ctx, logger, r.Client, instance, message, membershipDrift)
+}
+
+func (r *VolumeGroupReconciler) updateItems(ctx context.Context, instance *volumegroupv2.VolumeGroup, logger logr.Logger, groupCreationTime *metav1.Time, vgcName string) error {
+	vgc, err := utils.GetVolumeGroupContent(ctx, r.Client, logger, vgcName, instance.Name, instance.Namespace)
 	if err != nil {
-		return utils.HandleErrorMessage(logger, r.Client, instance, err, vgReconcile)
+		return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, vgReconcile)
 	}
-	if err = utils.UpdateVolumeGroupSourceContent(r.Client, instance, vgcName, logger); err != nil {
-		return utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, updateVGC)
+	if err = utils.UpdateVolumeGroupSourceContent(ctx, r.Client, instance, vgcName, logger); err != nil {
+		return utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, updateVGC)
 	}
-	if err = utils.UpdateVolumeGroupStatus(r.Client, instance, vgc, groupCreationTime, true, logger); err != nil {
-		return utils.HandleErrorMessage(logger, r.Client, instance, err, updateStatusVG)
+	if err = utils.UpdateVolumeGroupStatus(ctx, r.Client, instance, vgc, groupCreationTime, logger); err != nil {
+		return utils.HandleErrorMessage(ctx, logger, r.Client, instance, err, updateStatusVG)
 	}
-	if err = utils.AddFinalizerToVGC(r.Client, logger, vgc); err != nil {
-		return utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, updateVGC)
+	if err = utils.AddFinalizerToVGC(ctx, r.Client, logger, vgc); err != nil {
+		return utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, updateVGC)
 	}
-	if err = utils.UpdateVolumeGroupContentStatus(r.Client, logger, vgc, groupCreationTime, true); err != nil {
-		return utils.HandleVGCErrorMessage(logger, r.Client, vgc, err, updateStatusVGC)
+	if err = utils.UpdateVolumeGroupContentStatus(ctx, r.Client, logger, vgc, groupCreationTime); err != nil {
+		return utils.HandleVGCErrorMessage(ctx, logger, r.Client, vgc, err, updateStatusVGC)
 	}
 	return nil
 }
 
-func (r *VolumeGroupReconciler) removeInstance(logger logr.Logger, instance *volumegroupv1.VolumeGroup, secret map[string]string) error {
-	volumeGroupContent, err := utils.GetVolumeGroupContent(r.Client, logger, *instance.Spec.Source.VolumeGroupContentName, instance.Name, instance.Namespace)
+// isPVCDeletionEnabled checks if the persistentVolumeClaims of the volumeGroup are deleted with it, they are
+// retained when neither the volumeGroupClass nor the operator configuration asks to delete them.
+func (r *VolumeGroupReconciler) isPVCDeletionEnabled(vgClass *volumegroupv1.VolumeGroupClass) bool {
+	if vgClass.PersistentVolumeClaimDeletionPolicy != nil {
+		return *vgClass.PersistentVolumeClaimDeletionPolicy == volumegroupv1.PersistentVolumeClaimDelete
+	}
+	return r.DriverConfig.DisableDeletePvcs == "false"
+}
+
+func (r *VolumeGroupReconciler) removeInstance(ctx context.Context, logger logr.Logger, instance *volumegroupv2.VolumeGroup,
+	vgClass *volumegroupv1.VolumeGroupClass, secret map[string]string) error {
+	contentName := instance.Spec.Source.VolumeGroupContentName
+	if contentName == nil && instance.Annotations[utils.VolumeGroupHandleAnnotation] != "" {
+		// the creation stopped before the volumeGroup was bound, its content has the name of the group
+		volumeGroupName := instance.Annotations[utils.VolumeGroupNameAnnotation]
+		contentName = &volumeGroupName
+	}
+	if contentName == nil {
+		return utils.RemoveFinalizerFromVG(ctx, r.Client, logger, instance)
+	}
+	volumeGroupContent, err := utils.GetVolumeGroupContent(ctx, r.Client, logger, *contentName, instance.Name, instance.Namespace)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			return err
 		}
+		if err = r.removeUnboundStorageVolumeGroup(ctx, logger, instance, vgClass, secret); err != nil {
+			return err
+		}
 
-	} else {
-		err = r.removeVolumeGroupContent(logger, volumeGroupContent, secret)
+	} else if !utils.IsVolumeGroupContentBoundToOtherVG(volumeGroupContent, instance) {
+		err = r.removeVolumeGroupContent(ctx, logger, volumeGroupContent, secret)
 		if err != nil {
 			return err
 		}
 	}
 
-	if err = utils.RemoveFinalizerFromVG(r.Client, logger, instance); err != nil {
+	if err = utils.RemoveFinalizerFromVG(ctx, r.Client, logger, instance); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *VolumeGroupReconciler) removeVolumeGroupContent(logger logr.Logger, volumeGroupContent *volumegroupv1.VolumeGroupContent, secret map[string]string) error {
+// removeStorageVolumeGroupMembers empties the group on the storage before the persistentVolumeClaims of the
+// volumeGroup are deleted, so the driver never deletes a volume that is still a member of the group.
+func (r *VolumeGroupReconciler) removeStorageVolumeGroupMembers(ctx context.Context, logger logr.Logger,
+	instance *volumegroupv2.VolumeGroup, driver string, secret map[string]string) error {
+	if len(instance.Status.Members) == 0 {
+		return nil
+	}
+	volumeGroupId := instance.Annotations[utils.VolumeGroupHandleAnnotation]
+	if contentName := instance.Spec.Source.VolumeGroupContentName; contentName != nil {
+		volumeGroupContent, err := utils.GetVolumeGroupContent(ctx, r.Client, logger, *contentName, instance.Name, instance.Namespace)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			if utils.IsVolumeGroupContentBoundToOtherVG(volumeGroupContent, instance) || volumeGroupContent.Spec.Source == nil {
+				return nil
+			}
+			volumeGroupId = volumeGroupContent.Spec.Source.VolumeGroupHandle
+		}
+	}
+	if volumeGroupId == "" {
+		return nil
+	}
+	vgClient, ok := r.VolumeGroupClients[driver]
+	if !ok {
+		return fmt.Errorf(messages.DriverIsNotServed, driver)
+	}
+	return utils.RemoveVolumesFromDeletedVolumeGroup(ctx, logger, vgClient, volumeGroupId, secret)
+}
+
+// removeUnboundStorageVolumeGroup deletes the group that was created on the storage for a volumeGroup
+// whose volumeGroupContent was never created.
+func (r *VolumeGroupReconciler) removeUnboundStorageVolumeGroup(ctx context.Context, logger logr.Logger, instance *volumegroupv2.VolumeGroup,
+	vgClass *volumegroupv1.VolumeGroupClass, secret map[string]string) error {
+	volumeGroupId := instance.Annotations[utils.VolumeGroupHandleAnnotation]
+	if instance.Spec.Source.VolumeGroupContentName != nil || volumeGroupId == "" {
+		return nil
+	}
+	if vgClass.VolumeGroupDeletionPolicy != nil && *vgClass.VolumeGroupDeletionPolicy == volumegroupv1.VolumeGroupContentRetain {
+		logger.Info(fmt.Sprintf(messages.RetainVolumeGroupWithoutContent, volumeGroupId))
+		return nil
+	}
+	return r.deleteVolumeGroup(ctx, logger, vgClass.Driver, volumeGroupId, secret)
+}
+
+func (r *VolumeGroupReconciler) removeVolumeGroupContent(ctx context.Context, logger logr.Logger, volumeGroupContent *volumegroupv2.VolumeGroupContent, secret map[string]string) error {
+	if utils.IsVolumeGroupContentRetained(volumeGroupContent) {
+		return utils.ReleaseVolumeGroupContent(ctx, r.Client, logger, volumeGroupContent)
+	}
 	volumeGroupId := volumeGroupContent.Spec.Source.VolumeGroupHandle
-	if err := r.deleteVolumeGroup(logger, volumeGroupId, secret); err != nil {
+	if err := r.deleteVolumeGroup(ctx, logger, volumeGroupContent.Spec.Source.Driver, volumeGroupId, secret); err != nil {
+		if uErr := utils.HandleVGCErrorMessage(ctx, logger, r.Client, volumeGroupContent, err, deleteVG); uErr != nil {
+			return uErr
+		}
 		return err
 	}
-	err := r.RemoveVGCObject(logger, volumeGroupContent)
+	err := r.RemoveVGCObject(ctx, logger, volumeGroupContent)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *VolumeGroupReconciler) RemoveVGCObject(logger logr.Logger, volumeGroupContent *volumegroupv1.VolumeGroupContent) error {
-	if err := utils.RemoveFinalizerFromVGC(r.Client, logger, volumeGroupContent); err != nil {
+func (r *VolumeGroupReconciler) RemoveVGCObject(ctx context.Context, logger logr.Logger, volumeGroupContent *volumegroupv2.VolumeGroupContent) error {
+	if err := utils.RemoveFinalizerFromVGC(ctx, r.Client, logger, volumeGroupContent); err != nil {
 		return err
 	}
-	if err := r.Client.Delete(context.TODO(), volumeGroupContent); err != nil {
+	if err := r.Client.Delete(ctx, volumeGroupContent); err != nil {
 		logger.Error(err, "Failed to delete volume group content", "VGCName", volumeGroupContent.Name)
 		return err
 	}
@@ -258,81 +507,65 @@ func makeVolumeGroupName(prefix string, volumeGroupUID string) (string, error) {
 	return fmt.Sprintf("%s-%s", prefix, volumeGroupUID), nil
 }
 
-func (r *VolumeGroupReconciler) removeVolumesFromVG(logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
-	if len(vg.Status.PVCList) == 0 {
-		return nil
-	}
-
+func (r *VolumeGroupReconciler) getPVCsToRemoveFromVG(ctx context.Context, logger logr.Logger,
+	vg *volumegroupv2.VolumeGroup) ([]corev1.PersistentVolumeClaim, error) {
 	pvcsToRemove := []corev1.PersistentVolumeClaim{}
-	for _, pvcInList := range vg.Status.PVCList {
-		pvc, err := utils.GetPersistentVolumeClaim(logger, r.Client, pvcInList.Name, pvcInList.Namespace)
+	for _, member := range vg.Status.Members {
+		pvc, err := utils.GetPersistentVolumeClaim(ctx, logger, r.Client, member.Name, member.Namespace)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		isPVCShouldBeRemovedFromVg, err := r.isPVCShouldBeRemovedFromVg(logger, *vg, pvc)
+		isPVCShouldBeRemovedFromVg, err := r.isPVCShouldBeRemovedFromVg(ctx, logger, *vg, pvc)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if isPVCShouldBeRemovedFromVg {
 			pvcsToRemove = append(pvcsToRemove, *pvc)
 		}
 	}
-	return r.removeUnMatchedVolumes(logger, pvcsToRemove, vg)
+	return pvcsToRemove, nil
 }
 
-func (r *VolumeGroupReconciler) isPVCShouldBeRemovedFromVg(logger logr.Logger, vg volumegroupv1.VolumeGroup,
+func (r *VolumeGroupReconciler) isPVCShouldBeRemovedFromVg(ctx context.Context, logger logr.Logger, vg volumegroupv2.VolumeGroup,
 	pvc *corev1.PersistentVolumeClaim) (bool, error) {
-	if !utils.IsPVCPartOfVG(pvc, vg.Status.PVCList) {
+	if !utils.IsPVCPartOfVG(pvc, vg.Status.Members) {
 		return false, nil
 	}
 
-	isPVCMatchesVG, err := utils.IsPVCMatchesVG(logger, r.Client, pvc, vg)
+	isPVCMatchesVG, err := utils.IsPVCMatchesVG(ctx, logger, r.Client, pvc, vg)
 	if err != nil {
 		return false, err
 	}
 	return !isPVCMatchesVG, nil
 }
 
-func (r VolumeGroupReconciler) removeUnMatchedVolumes(logger logr.Logger, pvcs []corev1.PersistentVolumeClaim,
-	vg *volumegroupv1.VolumeGroup) error {
-	err := utils.RemoveVolumeFromVolumeGroup(logger, r.Client, r.VolumeGroupClient, pvcs, vg)
-	if err != nil {
-		return err
-	}
-	for _, pvc := range pvcs {
-		err = utils.RemoveVolumeFromPvcListAndPvList(logger, r.Client, r.DriverConfig.DriverName, &pvc, *vg)
-		return err
-	}
-	return nil
-}
-
-func (r *VolumeGroupReconciler) addMatchingVolumesToVG(logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
+func (r *VolumeGroupReconciler) getPVCsToAddToVG(ctx context.Context, logger logr.Logger, vg *volumegroupv2.VolumeGroup,
+	driver string) ([]corev1.PersistentVolumeClaim, error) {
 	pvcsToAdd := []corev1.PersistentVolumeClaim{}
-	pvcList, err := utils.GetPVCList(logger, r.Client, r.DriverConfig.DriverName)
+	pvcList, err := utils.GetMatchingPVCList(ctx, logger, r.Client, driver, vg)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, pvc := range pvcList.Items {
-		isPVCShouldBeAddedToVg, err := r.isPVCShouldBeAddedToVg(logger, *vg, &pvc)
+		isPVCShouldBeAddedToVg, err := r.isPVCShouldBeAddedToVg(ctx, logger, *vg, &pvc, driver)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if isPVCShouldBeAddedToVg {
 			pvcsToAdd = append(pvcsToAdd, pvc)
 		}
 	}
-
-	return r.addMatchedVolumes(logger, pvcsToAdd, vg)
+	return pvcsToAdd, nil
 }
 
-func (r *VolumeGroupReconciler) isPVCShouldBeAddedToVg(logger logr.Logger, vg volumegroupv1.VolumeGroup,
-	pvc *corev1.PersistentVolumeClaim) (bool, error) {
-	if utils.IsPVCPartOfVG(pvc, vg.Status.PVCList) {
+func (r *VolumeGroupReconciler) isPVCShouldBeAddedToVg(ctx context.Context, logger logr.Logger, vg volumegroupv2.VolumeGroup,
+	pvc *corev1.PersistentVolumeClaim, driver string) (bool, error) {
+	if utils.IsPVCPartOfVG(pvc, vg.Status.Members) {
 		return false, nil
 	}
 
-	isPVCMatchesVG, err := utils.IsPVCMatchesVG(logger, r.Client, pvc, vg)
+	isPVCMatchesVG, err := utils.IsPVCMatchesVG(ctx, logger, r.Client, pvc, vg)
 	if err != nil {
 		return false, err
 	}
@@ -340,48 +573,37 @@ func (r *VolumeGroupReconciler) isPVCShouldBeAddedToVg(logger logr.Logger, vg vo
 		return false, nil
 	}
 
-	if err := r.isPVCCanBeAddedToVG(logger, pvc); err != nil {
+	if err := r.isPVCCanBeAddedToVG(ctx, logger, pvc, driver); err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func (r VolumeGroupReconciler) isPVCCanBeAddedToVG(logger logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
+func (r VolumeGroupReconciler) isPVCCanBeAddedToVG(ctx context.Context, logger logr.Logger, pvc *corev1.PersistentVolumeClaim,
+	driver string) error {
 	if r.DriverConfig.MultipleVGsToPVC == "true" {
 		return nil
 	}
 
-	vgList, err := utils.GetVGList(logger, r.Client, r.DriverConfig.DriverName)
+	vgList, err := utils.GetVGList(ctx, logger, r.Client, driver)
 	if err != nil {
 		return err
 	}
-	err = utils.IsPVCCanBeAddedToVG(logger, r.Client, pvc, vgList.Items)
+	err = utils.IsPVCCanBeAddedToVG(ctx, logger, r.Client, pvc, vgList.Items)
 	return err
 }
 
-func (r VolumeGroupReconciler) addMatchedVolumes(logger logr.Logger, pvcs []corev1.PersistentVolumeClaim,
-	vg *volumegroupv1.VolumeGroup) error {
-	err := utils.AddVolumesToVolumeGroup(logger, r.Client, r.VolumeGroupClient, pvcs, vg)
-	if err != nil {
-		return err
-	}
-	for _, pvc := range pvcs {
-		err = utils.AddVolumeToPvcListAndPvList(logger, r.Client, &pvc, vg)
-		return err
-	}
-	return nil
-}
-
-func (r VolumeGroupReconciler) createSuccessVolumeGroupEvent(logger logr.Logger, vg *volumegroupv1.VolumeGroup) error {
+func (r VolumeGroupReconciler) createSuccessVolumeGroupEvent(ctx context.Context, logger logr.Logger, vg *volumegroupv2.VolumeGroup) error {
 	message := fmt.Sprintf(messages.VolumeGroupCreated, vg.Namespace, vg.Name)
-	err := utils.HandleSuccessMessage(logger, r.Client, vg, message, vgReconcile)
+	err := utils.HandleSuccessMessage(ctx, logger, r.Client, vg, message, vgReconcile)
 	if err != nil {
 		return nil
 	}
 	return nil
 }
 
-func (r *VolumeGroupReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.DriverConfig) error {
+// SetupWithManager sets up the controller, ctx is the context the manager runs with.
+func (r *VolumeGroupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, cfg *config.DriverConfig) error {
 	logger := r.Log.WithName("SetupWithManager")
 	err := r.waitForCrds(logger)
 	if err != nil {
@@ -391,11 +613,67 @@ func (r *VolumeGroupReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.D
 	}
 	pred := predicate.GenerationChangedPredicate{}
 
-	r.VolumeGroupClient = grpcClient.NewVolumeGroupClient(r.GRPCClient.Client, cfg.RPCTimeout)
+	r.VolumeGroupClients = grpcClient.NewVolumeGroupClients(r.GRPCClients, cfg.RPCTimeout)
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&volumegroupv1.VolumeGroup{}).
-		WithEventFilter(pred).Complete(r)
+		For(&volumegroupv2.VolumeGroup{}, builder.WithPredicates(pred)).
+		Watches(&source.Kind{Type: &corev1.PersistentVolumeClaim{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+			return r.getPVCVolumeGroupRequests(ctx, object)
+		}), builder.WithPredicates(utils.PVCMembershipPredicate)).
+		Watches(&source.Kind{Type: &volumegroupv1.VolumeGroupClass{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+			return r.getVolumeGroupClassVolumeGroupRequests(ctx, object)
+		}), builder.WithPredicates(pred)).
+		WithOptions(controller.Options{RateLimiter: utils.NewDriverRetryRateLimiter(cfg.RetryIntervalStart, cfg.RetryIntervalMax)}).
+		Complete(r)
+}
+
+// getPVCVolumeGroupRequests maps a persistentVolumeClaim to the volumeGroups that wait for their first
+// members. The membership of bound volumeGroups is changed by the persistentVolumeClaim controller, it
+// batches the changes of a volumeGroup into a single request to the driver.
+func (r *VolumeGroupReconciler) getPVCVolumeGroupRequests(ctx context.Context, object client.Object) []reconcile.Request {
+	pvc := object.(*corev1.PersistentVolumeClaim)
+	logger := r.Log.WithValues(messages.RequestNamespace, pvc.Namespace, messages.RequestName, pvc.Name)
+	driver, err := utils.GetPVCDriver(ctx, logger, r.Client, pvc)
+	if err != nil {
+		logger.Error(err, messages.FailedToMapPersistentVolumeClaimToVolumeGroups)
+		return nil
+	}
+	if !r.DriverConfig.IsDriverServed(driver) {
+		return nil
+	}
+	vgs, err := utils.GetVGsAffectedByPVC(ctx, logger, r.Client, driver, pvc)
+	if err != nil {
+		logger.Error(err, messages.FailedToMapPersistentVolumeClaimToVolumeGroups)
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, vg := range vgs {
+		if vg.Spec.Source.VolumeGroupContentName != nil {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&vg)})
+	}
+	return requests
+}
+
+// getVolumeGroupClassVolumeGroupRequests maps a volumeGroupClass to the volumeGroups that use it. Requests
+// that the driver rejected with a terminal error are not retried, they are retried once the class changes.
+func (r *VolumeGroupReconciler) getVolumeGroupClassVolumeGroupRequests(ctx context.Context, object client.Object) []reconcile.Request {
+	vgClass := object.(*volumegroupv1.VolumeGroupClass)
+	if !r.DriverConfig.IsDriverServed(vgClass.Driver) {
+		return nil
+	}
+	logger := r.Log.WithValues(messages.RequestName, vgClass.Name)
+	vgList, err := utils.GetVGListOfVolumeGroupClass(ctx, logger, r.Client, vgClass.Name)
+	if err != nil {
+		logger.Error(err, messages.FailedToMapVolumeGroupClassToVolumeGroups)
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, vg := range vgList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&vg)})
+	}
+	return requests
 }
 
 func (r *VolumeGroupReconciler) waitForCrds(logger logr.Logger) error {
@@ -446,16 +724,21 @@ func (r *VolumeGroupReconciler) waitForVolumeGroupResource(logger logr.Logger, r
 	}
 }
 
-func (r *VolumeGroupReconciler) deleteVolumeGroup(logger logr.Logger, volumeGroupId string, secrets map[string]string) error {
+func (r *VolumeGroupReconciler) deleteVolumeGroup(ctx context.Context, logger logr.Logger, driver, volumeGroupId string,
+	secrets map[string]string) error {
+	vgClient, ok := r.VolumeGroupClients[driver]
+	if !ok {
+		return fmt.Errorf(messages.DriverIsNotServed, driver)
+	}
 	param := volumegroup.CommonRequestParameters{
 		VolumeGroupID: volumeGroupId,
 		Secrets:       secrets,
-		VolumeGroup:   r.VolumeGroupClient,
+		VolumeGroup:   vgClient,
 	}
 
 	volumeGroupRequest := volumegroup.NewVolumeGroupRequest(param)
 
-	resp := volumeGroupRequest.Delete()
+	resp := volumeGroupRequest.Delete(ctx)
 
 	if resp.Error != nil {
 		logger.Error(resp.Error, "failed to delete volume group")
@@ -465,17 +748,18 @@ func (r *VolumeGroupReconciler) deleteVolumeGroup(logger logr.Logger, volumeGrou
 	return nil
 }
 
-func (r *VolumeGroupReconciler) createVolumeGroup(volumeGroupName string, parameters, secrets map[string]string) *volumegroup.Response {
+func (r *VolumeGroupReconciler) createVolumeGroup(ctx context.Context, driver, volumeGroupName string,
+	parameters, secrets map[string]string) *volumegroup.Response {
 	param := volumegroup.CommonRequestParameters{
 		Name:        volumeGroupName,
 		Parameters:  parameters,
 		Secrets:     secrets,
-		VolumeGroup: r.VolumeGroupClient,
+		VolumeGroup: r.VolumeGroupClients[driver],
 	}
 
 	volumeGroupRequest := volumegroup.NewVolumeGroupRequest(param)
 
-	resp := volumeGroupRequest.Create()
+	resp := volumeGroupRequest.Create(ctx)
 
 	return resp
 }
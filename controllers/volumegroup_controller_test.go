@@ -0,0 +1,385 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testDriver            = "driver.example.com"
+	testNamespace         = "default"
+	testVolumeGroupClass  = "volume-group-class"
+	testVolumeGroup       = "volume-group"
+	testVolumeGroupHandle = "volume-group-handle"
+)
+
+var testPVCNames = []string{"pvc-0", "pvc-1"}
+
+// TestVolumeGroupDeletionPVCPolicy deletes a volumeGroup and checks which of the policies of the
+// volumeGroupClass and the operator configuration delete its persistentVolumeClaims.
+func TestVolumeGroupDeletionPVCPolicy(t *testing.T) {
+	deletePolicy := volumegroupv1.PersistentVolumeClaimDelete
+	retainPolicy := volumegroupv1.PersistentVolumeClaimRetain
+	tests := []struct {
+		name              string
+		policy            *volumegroupv1.PersistentVolumeClaimDeletionPolicy
+		disableDeletePvcs string
+		expectDeleted     bool
+	}{
+		{name: "class without policy keeps the claims", disableDeletePvcs: "true"},
+		{name: "class without policy and unset configuration keeps the claims"},
+		{name: "class without policy and configuration enabling deletion", disableDeletePvcs: "false", expectDeleted: true},
+		{name: "class with Retain policy keeps the claims", policy: &retainPolicy, disableDeletePvcs: "false"},
+		{name: "class with Delete policy deletes the claims", policy: &deletePolicy, disableDeletePvcs: "true", expectDeleted: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := newDeletedVolumeGroupClient(t, tt.policy)
+			cfg := config.NewDriverConfig()
+			cfg.DriverName = testDriver
+			cfg.DriverEndpoint = "unused"
+			cfg.DisableDeletePvcs = tt.disableDeletePvcs
+			emptiedGroups := []string{}
+			vgClient := &storageVolumeGroupClient{onModify: func(volumeGroupId string, volumeIds []string) {
+				if len(volumeIds) != 0 {
+					t.Errorf("expected the group to be emptied, got volumes %v", volumeIds)
+				}
+				pvcList := &corev1.PersistentVolumeClaimList{}
+				if err := k8sClient.List(ctx, pvcList); err != nil || len(pvcList.Items) != len(testPVCNames) {
+					t.Errorf("the group was emptied after its persistentVolumeClaims were deleted")
+				}
+				emptiedGroups = append(emptiedGroups, volumeGroupId)
+			}}
+			r := &VolumeGroupReconciler{
+				Client:             k8sClient,
+				APIReader:          k8sClient,
+				Log:                logr.Discard(),
+				Scheme:             k8sClient.Scheme(),
+				DriverConfig:       cfg,
+				GRPCClients:        map[string]*grpcClient.Client{testDriver: {Client: newReadyConn(t)}},
+				VolumeGroupClients: map[string]grpcClient.VolumeGroup{testDriver: vgClient},
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: testVolumeGroup, Namespace: testNamespace}}
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			if tt.expectDeleted && !reflect.DeepEqual(emptiedGroups, []string{testVolumeGroupHandle}) {
+				t.Errorf("expected %s to be emptied on the storage, got %v", testVolumeGroupHandle, emptiedGroups)
+			} else if !tt.expectDeleted && len(emptiedGroups) > 0 {
+				t.Errorf("expected the group to keep its volumes, got emptied groups %v", emptiedGroups)
+			}
+
+			for _, pvcName := range testPVCNames {
+				pvc := &corev1.PersistentVolumeClaim{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: testNamespace}, pvc)
+				isDeleted := apierrors.IsNotFound(err) || (err == nil && !pvc.DeletionTimestamp.IsZero())
+				if err != nil && !apierrors.IsNotFound(err) {
+					t.Fatal(err)
+				}
+				if isDeleted != tt.expectDeleted {
+					t.Errorf("persistentVolumeClaim %s deleted: %t, expected %t", pvcName, isDeleted, tt.expectDeleted)
+				}
+			}
+		})
+	}
+}
+
+// TestPVCEventsEnqueueOnlyUnboundVolumeGroups checks that persistentVolumeClaim events do not reconcile
+// bound volumeGroups, their membership is changed by the batches of the persistentVolumeClaim controller.
+func TestPVCEventsEnqueueOnlyUnboundVolumeGroups(t *testing.T) {
+	const boundVolumeGroup = "bound-volume-group"
+	scheme := newTestScheme(t)
+	vgClassName := testVolumeGroupClass
+	vgcName := boundVolumeGroup
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"group": testVolumeGroup}}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: testPVCNames[0], Namespace: testNamespace,
+			Labels: map[string]string{"group": testVolumeGroup}},
+		Spec:   corev1.PersistentVolumeClaimSpec{VolumeName: "pv-0"},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&volumegroupv1.VolumeGroupClass{ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroupClass}, Driver: testDriver},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-0"},
+			Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: testDriver, VolumeHandle: "volume-0"},
+			}},
+		},
+		pvc,
+		&volumegroupv2.VolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroup, Namespace: testNamespace},
+			Spec: volumegroupv2.VolumeGroupSpec{VolumeGroupClassName: &vgClassName,
+				Source: volumegroupv2.VolumeGroupSource{Selector: selector}},
+		},
+		&volumegroupv2.VolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: boundVolumeGroup, Namespace: testNamespace},
+			Spec: volumegroupv2.VolumeGroupSpec{VolumeGroupClassName: &vgClassName,
+				Source: volumegroupv2.VolumeGroupSource{Selector: selector, VolumeGroupContentName: &vgcName}},
+		},
+	).Build()
+	cfg := config.NewDriverConfig()
+	cfg.DriverName = testDriver
+	cfg.DriverEndpoint = "unused"
+	r := &VolumeGroupReconciler{Client: k8sClient, Log: logr.Discard(), DriverConfig: cfg}
+
+	requests := r.getPVCVolumeGroupRequests(context.Background(), pvc)
+	expected := reconcile.Request{NamespacedName: types.NamespacedName{Name: testVolumeGroup, Namespace: testNamespace}}
+	if len(requests) != 1 || requests[0] != expected {
+		t.Errorf("expected only %s to be enqueued, got %v", expected, requests)
+	}
+}
+
+// TestVolumeGroupClassEventsEnqueueItsVolumeGroups checks that a change of a volumeGroupClass reconciles
+// its volumeGroups again, a volumeGroup that failed with a terminal driver error is not retried otherwise.
+func TestVolumeGroupClassEventsEnqueueItsVolumeGroups(t *testing.T) {
+	vgClassName := testVolumeGroupClass
+	vgClass := &volumegroupv1.VolumeGroupClass{ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroupClass}, Driver: testDriver}
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		vgClass,
+		&volumegroupv2.VolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroup, Namespace: testNamespace},
+			Spec:       volumegroupv2.VolumeGroupSpec{VolumeGroupClassName: &vgClassName},
+		},
+	).Build()
+	cfg := config.NewDriverConfig()
+	cfg.DriverName = testDriver
+	cfg.DriverEndpoint = "unused"
+	r := &VolumeGroupReconciler{Client: k8sClient, Log: logr.Discard(), DriverConfig: cfg}
+
+	requests := r.getVolumeGroupClassVolumeGroupRequests(context.Background(), vgClass)
+	expected := reconcile.Request{NamespacedName: types.NamespacedName{Name: testVolumeGroup, Namespace: testNamespace}}
+	if len(requests) != 1 || requests[0] != expected {
+		t.Errorf("expected %s to be enqueued, got %v", expected, requests)
+	}
+
+	otherDriverClass := &volumegroupv1.VolumeGroupClass{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Driver: "other.example.com"}
+	if requests = r.getVolumeGroupClassVolumeGroupRequests(context.Background(), otherDriverClass); len(requests) != 0 {
+		t.Errorf("expected the volumeGroupClass of another driver to enqueue nothing, got %v", requests)
+	}
+}
+
+// storageVolumeGroupClient is a driver that holds the groups on the storage and records the groups it creates
+// and deletes.
+type storageVolumeGroupClient struct {
+	volumeGroups  []*csi.VolumeGroup
+	listErr       error
+	createdGroups []string
+	deletedGroups []string
+	// onModify is called with the volume IDs of every ModifyVolumeGroupMembership call.
+	onModify func(volumeGroupId string, volumeIds []string)
+}
+
+func (c *storageVolumeGroupClient) CreateVolumeGroup(_ context.Context, name string, _, _ map[string]string) (*csi.CreateVolumeGroupResponse, error) {
+	c.createdGroups = append(c.createdGroups, name)
+	return &csi.CreateVolumeGroupResponse{VolumeGroup: &csi.VolumeGroup{VolumeGroupId: "created-" + name}}, nil
+}
+
+func (c *storageVolumeGroupClient) DeleteVolumeGroup(_ context.Context, volumeGroupId string, _ map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
+	c.deletedGroups = append(c.deletedGroups, volumeGroupId)
+	return &csi.DeleteVolumeGroupResponse{}, nil
+}
+
+func (c *storageVolumeGroupClient) ModifyVolumeGroupMembership(_ context.Context, volumeGroupId string, volumeIds []string,
+	_ map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
+	if c.onModify == nil {
+		return nil, status.Error(codes.Unimplemented, "")
+	}
+	c.onModify(volumeGroupId, volumeIds)
+	return &csi.ModifyVolumeGroupMembershipResponse{}, nil
+}
+
+func (c *storageVolumeGroupClient) ControllerGetVolumeGroup(context.Context, string, map[string]string) (*csi.ControllerGetVolumeGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "")
+}
+
+func (c *storageVolumeGroupClient) ListVolumeGroups(context.Context, int32, string, map[string]string) (*csi.ListVolumeGroupsResponse, error) {
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	resp := &csi.ListVolumeGroupsResponse{}
+	for _, vg := range c.volumeGroups {
+		resp.Entries = append(resp.Entries, &csi.ListVolumeGroupsResponse_Entry{VolumeGroup: vg})
+	}
+	return resp, nil
+}
+
+// TestResumeVolumeGroupCreationWithSavedName resumes the creation of a volumeGroup whose operator stopped
+// after saving the name of the group and before saving its handle.
+func TestResumeVolumeGroupCreationWithSavedName(t *testing.T) {
+	const volumeGroupName = "volumegroup-0b1c2d3e-4f50-6172-8394-a5b6c7d8e9f0"
+	tests := []struct {
+		name               string
+		vgClient           *storageVolumeGroupClient
+		expectedHandle     string
+		expectedCreatedVGs []string
+	}{
+		{
+			name:           "group exists with the name as ID",
+			vgClient:       &storageVolumeGroupClient{volumeGroups: []*csi.VolumeGroup{{VolumeGroupId: volumeGroupName}}},
+			expectedHandle: volumeGroupName,
+		},
+		{
+			name: "group exists with the name in its context",
+			vgClient: &storageVolumeGroupClient{volumeGroups: []*csi.VolumeGroup{
+				{VolumeGroupId: "other-group"},
+				{VolumeGroupId: "1234", VolumeGroupContext: map[string]string{"name": volumeGroupName}},
+			}},
+			expectedHandle: "1234",
+		},
+		{
+			name:               "group does not exist",
+			vgClient:           &storageVolumeGroupClient{volumeGroups: []*csi.VolumeGroup{{VolumeGroupId: "other-group"}}},
+			expectedHandle:     "created-" + volumeGroupName,
+			expectedCreatedVGs: []string{volumeGroupName},
+		},
+		{
+			name:               "driver cannot list groups",
+			vgClient:           &storageVolumeGroupClient{listErr: status.Error(codes.Unimplemented, "")},
+			expectedHandle:     "created-" + volumeGroupName,
+			expectedCreatedVGs: []string{volumeGroupName},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			vgClassName := testVolumeGroupClass
+			vg := &volumegroupv2.VolumeGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroup, Namespace: testNamespace,
+					Annotations: map[string]string{utils.VolumeGroupNameAnnotation: volumeGroupName}},
+				Spec: volumegroupv2.VolumeGroupSpec{VolumeGroupClassName: &vgClassName},
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(vg).Build()
+			r := &VolumeGroupReconciler{
+				Client:             k8sClient,
+				Log:                logr.Discard(),
+				VolumeGroupClients: map[string]grpcClient.VolumeGroup{testDriver: tt.vgClient},
+			}
+
+			storageVG, err := r.getOrCreateStorageVolumeGroup(ctx, logr.Discard(), vg, testDriver, volumeGroupName, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if storageVG.GetVolumeGroupId() != tt.expectedHandle {
+				t.Errorf("expected group %s, got %s", tt.expectedHandle, storageVG.GetVolumeGroupId())
+			}
+			if !reflect.DeepEqual(tt.vgClient.createdGroups, tt.expectedCreatedVGs) {
+				t.Errorf("expected created groups %v, got %v", tt.expectedCreatedVGs, tt.vgClient.createdGroups)
+			}
+			savedVG := &volumegroupv2.VolumeGroup{}
+			if err = k8sClient.Get(ctx, client.ObjectKeyFromObject(vg), savedVG); err != nil {
+				t.Fatal(err)
+			}
+			if handle := savedVG.Annotations[utils.VolumeGroupHandleAnnotation]; handle != tt.expectedHandle {
+				t.Errorf("expected saved handle %s, got %s", tt.expectedHandle, handle)
+			}
+		})
+	}
+}
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := volumegroupv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := volumegroupv2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func newDeletedVolumeGroupClient(t *testing.T, policy *volumegroupv1.PersistentVolumeClaimDeletionPolicy) client.Client {
+	scheme := newTestScheme(t)
+	vgClassName := testVolumeGroupClass
+	deletionTime := metav1.Now()
+	vg := &volumegroupv2.VolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroup, Namespace: testNamespace,
+			DeletionTimestamp: &deletionTime, Finalizers: []string{utils.VolumeGroupFinalizer},
+			Annotations: map[string]string{utils.VolumeGroupHandleAnnotation: testVolumeGroupHandle}},
+		Spec: volumegroupv2.VolumeGroupSpec{
+			VolumeGroupClassName: &vgClassName,
+			Source: volumegroupv2.VolumeGroupSource{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"group": testVolumeGroup}},
+			},
+		},
+	}
+	objects := []client.Object{
+		&volumegroupv1.VolumeGroupClass{ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroupClass}, Driver: testDriver,
+			PersistentVolumeClaimDeletionPolicy: policy},
+	}
+	for _, pvcName := range testPVCNames {
+		vg.Status.Members = append(vg.Status.Members, volumegroupv2.VolumeGroupMember{Name: pvcName, Namespace: testNamespace})
+		objects = append(objects, &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: testNamespace,
+				Labels: map[string]string{"group": testVolumeGroup}},
+			Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		})
+	}
+	objects = append(objects, vg)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+// newReadyConn returns a connection to a driver without services, the connection only has to be ready
+// for the driver to count as connected.
+func newReadyConn(t *testing.T) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.Dial("passthrough:///driver", grpc.WithBlock(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumegroupcontent
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// VolumeGroupContentReconciler keeps the phase of volumeGroupContents that are not bound
+// by the volumeGroup controller, contents that were created by an admin and contents
+// whose volumeGroup is gone.
+type VolumeGroupContentReconciler struct {
+	Client       client.Client
+	Scheme       *runtime.Scheme
+	Log          logr.Logger
+	DriverConfig *config.DriverConfig
+}
+
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupcontents,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=csi.ibm.com,resources=volumegroupcontents/status,verbs=get;update;patch
+
+func (r *VolumeGroupContentReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	logger := r.Log.WithValues(messages.RequestName, req.Name)
+	logger.Info(messages.ReconcileVolumeGroupContent)
+
+	vgc := &volumegroupv2.VolumeGroupContent{}
+	if err := r.Client.Get(ctx, req.NamespacedName, vgc); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if vgc.Spec.Source == nil || !r.DriverConfig.IsDriverServed(vgc.Spec.Source.Driver) ||
+		!vgc.GetDeletionTimestamp().IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	phase, message, err := r.getVolumeGroupContentPhase(ctx, logger, vgc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if phase == "" || phase == vgc.Status.Phase {
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{}, utils.UpdateVolumeGroupContentStatusPhase(ctx, r.Client, logger, vgc, phase, message)
+}
+
+func (r *VolumeGroupContentReconciler) getVolumeGroupContentPhase(ctx context.Context, logger logr.Logger,
+	vgc *volumegroupv2.VolumeGroupContent) (volumegroupv2.VolumeGroupContentPhase, string, error) {
+	vgRef := vgc.Spec.VolumeGroupRef
+	if vgRef == nil || (vgRef.UID == "" && vgc.Status.Phase == "") {
+		return volumegroupv2.VolumeGroupContentAvailable, messages.VolumeGroupContentAvailable, nil
+	}
+	if vgc.Status.Phase != volumegroupv2.VolumeGroupContentBound || vgRef.UID == "" {
+		return "", "", nil
+	}
+
+	vg := &volumegroupv2.VolumeGroup{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: vgRef.Name, Namespace: vgRef.Namespace}, vg)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", "", err
+	}
+	if errors.IsNotFound(err) || vg.UID != vgRef.UID || isBoundToOtherVolumeGroupContent(vg, vgc) {
+		logger.Info(fmt.Sprintf(messages.VolumeGroupContentReleased, vgRef.Namespace, vgRef.Name))
+		return volumegroupv2.VolumeGroupContentReleased,
+			fmt.Sprintf(messages.VolumeGroupContentReleased, vgRef.Namespace, vgRef.Name), nil
+	}
+	return "", "", nil
+}
+
+func isBoundToOtherVolumeGroupContent(vg *volumegroupv2.VolumeGroup, vgc *volumegroupv2.VolumeGroupContent) bool {
+	return vg.Status.BoundVolumeGroupContentName != nil && *vg.Status.BoundVolumeGroupContentName != vgc.Name
+}
+
+func (r *VolumeGroupContentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&volumegroupv2.VolumeGroupContent{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &volumegroupv2.VolumeGroup{}}, handler.EnqueueRequestsFromMapFunc(getVolumeGroupVolumeGroupContentRequests)).
+		Complete(r)
+}
+
+// getVolumeGroupVolumeGroupContentRequests maps a volumeGroup to the volumeGroupContents it references,
+// so a content is released when its volumeGroup is deleted or stops referencing it.
+func getVolumeGroupVolumeGroupContentRequests(object client.Object) []reconcile.Request {
+	vg := object.(*volumegroupv2.VolumeGroup)
+	requests := []reconcile.Request{}
+	for _, vgcName := range []*string{vg.Status.BoundVolumeGroupContentName, vg.Spec.Source.VolumeGroupContentName} {
+		if vgcName == nil || *vgcName == "" {
+			continue
+		}
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Name: *vgcName}}
+		if len(requests) == 0 || requests[0] != request {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumegroupcontent
+
+import (
+	"context"
+	"testing"
+
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testDriver             = "driver.example.com"
+	testNamespace          = "default"
+	testVolumeGroup        = "volume-group"
+	testVolumeGroupContent = "volume-group-content"
+)
+
+func TestDeletedVolumeGroupReleasesItsContent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := volumegroupv2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	vgcName := testVolumeGroupContent
+	vg := &volumegroupv2.VolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroup, Namespace: testNamespace, UID: "volume-group-uid"},
+		Status:     volumegroupv2.VolumeGroupStatus{BoundVolumeGroupContentName: &vgcName},
+	}
+	vgc := &volumegroupv2.VolumeGroupContent{
+		ObjectMeta: metav1.ObjectMeta{Name: testVolumeGroupContent},
+		Spec: volumegroupv2.VolumeGroupContentSpec{
+			VolumeGroupRef: &corev1.ObjectReference{Name: vg.Name, Namespace: vg.Namespace, UID: vg.UID},
+			Source:         &volumegroupv2.VolumeGroupContentSource{Driver: testDriver, VolumeGroupHandle: "handle"},
+		},
+		Status: volumegroupv2.VolumeGroupContentStatus{Phase: volumegroupv2.VolumeGroupContentBound},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vg, vgc).Build()
+	cfg := config.NewDriverConfig()
+	cfg.DriverName = testDriver
+	cfg.DriverEndpoint = "unused"
+	r := &VolumeGroupContentReconciler{Client: k8sClient, Scheme: scheme, Log: logr.Discard(), DriverConfig: cfg}
+	ctx := context.Background()
+
+	requests := getVolumeGroupVolumeGroupContentRequests(vg)
+	expected := reconcile.Request{NamespacedName: types.NamespacedName{Name: testVolumeGroupContent}}
+	if len(requests) != 1 || requests[0] != expected {
+		t.Fatalf("expected %s to be enqueued, got %v", expected, requests)
+	}
+
+	assertVolumeGroupContentPhase(t, r, expected, volumegroupv2.VolumeGroupContentBound)
+	if err := k8sClient.Delete(ctx, vg); err != nil {
+		t.Fatal(err)
+	}
+	assertVolumeGroupContentPhase(t, r, expected, volumegroupv2.VolumeGroupContentReleased)
+}
+
+func assertVolumeGroupContentPhase(t *testing.T, r *VolumeGroupContentReconciler, request reconcile.Request,
+	expected volumegroupv2.VolumeGroupContentPhase) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatal(err)
+	}
+	vgc := &volumegroupv2.VolumeGroupContent{}
+	if err := r.Client.Get(ctx, request.NamespacedName, vgc); err != nil {
+		t.Fatal(err)
+	}
+	if vgc.Status.Phase != expected {
+		t.Errorf("expected the volumeGroupContent phase to be %s, got %s", expected, vgc.Status.Phase)
+	}
+}
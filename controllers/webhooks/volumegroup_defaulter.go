@@ -0,0 +1,60 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:webhook:path=/mutate-csi-ibm-com-v1-volumegroup,mutating=true,failurePolicy=fail,sideEffects=None,groups=csi.ibm.com,resources=volumegroups,verbs=create,versions=v1,name=mvolumegroup.csi.ibm.com,admissionReviewVersions=v1
+
+// VolumeGroupDefaulter fills in the default VolumeGroupClass of the drivers
+// when a VolumeGroup is created without volumeGroupClassName.
+type VolumeGroupDefaulter struct {
+	Client  client.Client
+	Log     logr.Logger
+	Drivers []string
+}
+
+func (d *VolumeGroupDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	vg, err := toVolumeGroup(obj)
+	if err != nil {
+		return err
+	}
+	if vg.Spec.VolumeGroupClassName != nil && *vg.Spec.VolumeGroupClassName != "" {
+		return nil
+	}
+
+	logger := d.Log.WithValues("Namespace", vg.Namespace, "Name", vg.Name)
+	vgClass, err := utils.GetDefaultVolumeGroupClass(ctx, d.Client, logger, d.Drivers)
+	if err != nil {
+		var noDefaultErr *vgerrors.NoDefaultVolumeGroupClassError
+		if errors.As(err, &noDefaultErr) {
+			return nil
+		}
+		return err
+	}
+	vg.Spec.VolumeGroupClassName = &vgClass.Name
+	return nil
+}
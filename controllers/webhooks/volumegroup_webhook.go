@@ -0,0 +1,176 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:webhook:path=/validate-csi-ibm-com-v1-volumegroup,mutating=false,failurePolicy=fail,sideEffects=None,groups=csi.ibm.com,resources=volumegroups,verbs=create;update,versions=v1,name=vvolumegroup.csi.ibm.com,admissionReviewVersions=v1
+
+type VolumeGroupValidator struct {
+	Client client.Client
+}
+
+func (v *VolumeGroupValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	vg, err := toVolumeGroup(obj)
+	if err != nil {
+		return err
+	}
+	allErrs := validateVolumeGroupSource(vg.Spec.Source, field.NewPath("spec", "source"))
+	allErrs = append(allErrs, v.validateVolumeGroupClass(ctx, vg.Spec.VolumeGroupClassName,
+		field.NewPath("spec", "volumeGroupClassName"))...)
+	return toInvalidError(volumeGroupKind, vg.Name, allErrs)
+}
+
+func (v *VolumeGroupValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldVG, err := toVolumeGroup(oldObj)
+	if err != nil {
+		return err
+	}
+	vg, err := toVolumeGroup(newObj)
+	if err != nil {
+		return err
+	}
+	if !vg.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	allErrs := validateVolumeGroupSourceUpdate(oldVG, vg, field.NewPath("spec", "source"))
+	classPath := field.NewPath("spec", "volumeGroupClassName")
+	if !equality.Semantic.DeepEqual(oldVG.Spec.VolumeGroupClassName, vg.Spec.VolumeGroupClassName) {
+		if isVolumeGroupBound(oldVG) {
+			allErrs = append(allErrs, field.Forbidden(classPath, messages.FieldIsImmutableAfterBinding))
+		} else {
+			allErrs = append(allErrs, v.validateVolumeGroupClass(ctx, vg.Spec.VolumeGroupClassName, classPath)...)
+		}
+	}
+	return toInvalidError(volumeGroupKind, vg.Name, allErrs)
+}
+
+func (v *VolumeGroupValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func validateVolumeGroupSource(source volumegroupv1.VolumeGroupSource, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	hasNames := len(source.PersistentVolumeClaimNames) > 0
+	if source.VolumeGroupContentName != nil && source.Selector != nil {
+		allErrs = append(allErrs, field.Invalid(path, source, messages.VolumeGroupSourceHasBothOptions))
+	} else if source.Selector != nil && hasNames {
+		allErrs = append(allErrs, field.Invalid(path, source, messages.VolumeGroupSourceHasSelectorAndNames))
+	} else if source.VolumeGroupContentName == nil && source.Selector == nil && !hasNames {
+		allErrs = append(allErrs, field.Required(path, messages.VolumeGroupSourceHasNoOption))
+	}
+	if source.VolumeGroupContentName != nil && *source.VolumeGroupContentName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("volumeGroupContentName"), ""))
+	}
+	if source.Selector != nil {
+		allErrs = append(allErrs, validateSelector(source.Selector, path.Child("selector"))...)
+	}
+	allErrs = append(allErrs, validatePersistentVolumeClaimNames(source.PersistentVolumeClaimNames,
+		path.Child("persistentVolumeClaimNames"))...)
+	return allErrs
+}
+
+func validatePersistentVolumeClaimNames(names []string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seenNames := map[string]bool{}
+	for i, name := range names {
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), name, msg))
+		}
+		if seenNames[name] {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), name))
+		}
+		seenNames[name] = true
+	}
+	return allErrs
+}
+
+func validateSelector(selector *metav1.LabelSelector, path *field.Path) field.ErrorList {
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return field.ErrorList{field.Invalid(path, selector, err.Error())}
+	}
+	return nil
+}
+
+// validateVolumeGroupSourceUpdate allows the controller to record the content it created for a
+// dynamically provisioned group. After binding only the explicit member list can be changed.
+func validateVolumeGroupSourceUpdate(oldVG, vg *volumegroupv1.VolumeGroup, path *field.Path) field.ErrorList {
+	oldSource, source := oldVG.Spec.Source, vg.Spec.Source
+	if equality.Semantic.DeepEqual(oldSource, source) {
+		return nil
+	}
+	if isVolumeGroupBound(oldVG) {
+		oldSource.PersistentVolumeClaimNames = source.PersistentVolumeClaimNames
+		if !equality.Semantic.DeepEqual(oldSource, source) {
+			return field.ErrorList{field.Forbidden(path, messages.OnlyMembersCanChangeAfterBinding)}
+		}
+		return validatePersistentVolumeClaimNames(source.PersistentVolumeClaimNames,
+			path.Child("persistentVolumeClaimNames"))
+	}
+	if oldSource.VolumeGroupContentName == nil && source.VolumeGroupContentName != nil &&
+		equality.Semantic.DeepEqual(oldSource.Selector, source.Selector) && source.Selector != nil {
+		source.VolumeGroupContentName = nil
+	}
+	return validateVolumeGroupSource(source, path)
+}
+
+func (v *VolumeGroupValidator) validateVolumeGroupClass(ctx context.Context, className *string,
+	path *field.Path) field.ErrorList {
+	if className == nil || *className == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	vgClass := &volumegroupv1.VolumeGroupClass{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: *className}, vgClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.ErrorList{field.NotFound(path, *className)}
+		}
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	if err := utils.ValidatePrefixedParameters(vgClass.Parameters); err != nil {
+		return field.ErrorList{field.Invalid(path, *className,
+			fmt.Sprintf(messages.VolumeGroupClassHasInvalidParameters, err.Error()))}
+	}
+	return nil
+}
+
+func isVolumeGroupBound(vg *volumegroupv1.VolumeGroup) bool {
+	return vg.Status.BoundVolumeGroupContentName != nil
+}
+
+func toVolumeGroup(obj runtime.Object) (*volumegroupv1.VolumeGroup, error) {
+	vg, ok := obj.(*volumegroupv1.VolumeGroup)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf(messages.UnexpectedObjectType, volumeGroupKind, obj))
+	}
+	return vg, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+//+kubebuilder:webhook:path=/validate-csi-ibm-com-v1-volumegroupclass,mutating=false,failurePolicy=fail,sideEffects=None,groups=csi.ibm.com,resources=volumegroupclasses,verbs=create;update,versions=v1,name=vvolumegroupclass.csi.ibm.com,admissionReviewVersions=v1
+
+type VolumeGroupClassValidator struct{}
+
+func (v *VolumeGroupClassValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	vgClass, err := toVolumeGroupClass(obj)
+	if err != nil {
+		return err
+	}
+	return toInvalidError(volumeGroupClassKind, vgClass.Name, validateVolumeGroupClass(vgClass))
+}
+
+func (v *VolumeGroupClassValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) error {
+	vgClass, err := toVolumeGroupClass(newObj)
+	if err != nil {
+		return err
+	}
+	if !vgClass.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	return toInvalidError(volumeGroupClassKind, vgClass.Name, validateVolumeGroupClass(vgClass))
+}
+
+func (v *VolumeGroupClassValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func validateVolumeGroupClass(vgClass *volumegroupv1.VolumeGroupClass) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if vgClass.Driver == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("driver"), ""))
+	}
+	if err := utils.ValidatePrefixedParameters(vgClass.Parameters); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("parameters"), vgClass.Parameters, err.Error()))
+	}
+	allErrs = append(allErrs, validateDeletionPolicy(vgClass.VolumeGroupDeletionPolicy,
+		field.NewPath("volumeGroupDeletionPolicy"))...)
+	return allErrs
+}
+
+func validateDeletionPolicy(policy *volumegroupv1.VolumeGroupDeletionPolicy, path *field.Path) field.ErrorList {
+	if policy == nil {
+		return nil
+	}
+	switch *policy {
+	case volumegroupv1.VolumeGroupContentDelete, volumegroupv1.VolumeGroupContentRetain:
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(path, *policy, []string{
+			string(volumegroupv1.VolumeGroupContentDelete), string(volumegroupv1.VolumeGroupContentRetain)})}
+	}
+}
+
+func toVolumeGroupClass(obj runtime.Object) (*volumegroupv1.VolumeGroupClass, error) {
+	vgClass, ok := obj.(*volumegroupv1.VolumeGroupClass)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf(messages.UnexpectedObjectType, volumeGroupClassKind, obj))
+	}
+	return vgClass, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+//+kubebuilder:webhook:path=/validate-csi-ibm-com-v1-volumegroupcontent,mutating=false,failurePolicy=fail,sideEffects=None,groups=csi.ibm.com,resources=volumegroupcontents,verbs=create;update,versions=v1,name=vvolumegroupcontent.csi.ibm.com,admissionReviewVersions=v1
+
+type VolumeGroupContentValidator struct{}
+
+func (v *VolumeGroupContentValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	vgc, err := toVolumeGroupContent(obj)
+	if err != nil {
+		return err
+	}
+	return toInvalidError(volumeGroupContentKind, vgc.Name, validateVolumeGroupContentSpec(vgc.Spec))
+}
+
+func (v *VolumeGroupContentValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	oldVGC, err := toVolumeGroupContent(oldObj)
+	if err != nil {
+		return err
+	}
+	vgc, err := toVolumeGroupContent(newObj)
+	if err != nil {
+		return err
+	}
+	if !vgc.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	allErrs := validateVolumeGroupContentSpec(vgc.Spec)
+	if oldVGC.Spec.Source != nil && vgc.Spec.Source != nil &&
+		oldVGC.Spec.Source.VolumeGroupHandle != vgc.Spec.Source.VolumeGroupHandle {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "source", "volumeGroupHandle"),
+			messages.FieldIsImmutable))
+	}
+	if oldVGC.Spec.VolumeGroupClassName != nil &&
+		!equality.Semantic.DeepEqual(oldVGC.Spec.VolumeGroupClassName, vgc.Spec.VolumeGroupClassName) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "volumeGroupClassName"),
+			messages.FieldIsImmutableAfterBinding))
+	}
+	return toInvalidError(volumeGroupContentKind, vgc.Name, allErrs)
+}
+
+func (v *VolumeGroupContentValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func validateVolumeGroupContentSpec(spec volumegroupv1.VolumeGroupContentSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	sourcePath := field.NewPath("spec", "source")
+	if spec.Source == nil {
+		allErrs = append(allErrs, field.Required(sourcePath, ""))
+	} else {
+		if spec.Source.Driver == "" {
+			allErrs = append(allErrs, field.Required(sourcePath.Child("driver"), ""))
+		}
+		if spec.Source.VolumeGroupHandle == "" {
+			allErrs = append(allErrs, field.Required(sourcePath.Child("volumeGroupHandle"), ""))
+		}
+	}
+	if spec.VolumeGroupRef != nil {
+		refPath := field.NewPath("spec", "volumeGroupRef")
+		if spec.VolumeGroupRef.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+		}
+		if spec.VolumeGroupRef.Namespace == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("namespace"), ""))
+		}
+	}
+	allErrs = append(allErrs, validateDeletionPolicy(spec.VolumeGroupDeletionPolicy,
+		field.NewPath("spec", "volumeGroupDeletionPolicy"))...)
+	return allErrs
+}
+
+func toVolumeGroupContent(obj runtime.Object) (*volumegroupv1.VolumeGroupContent, error) {
+	vgc, ok := obj.(*volumegroupv1.VolumeGroupContent)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf(messages.UnexpectedObjectType, volumeGroupContentKind, obj))
+	}
+	return vgc, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	"github.com/IBM/csi-volume-group-operator/pkg/config"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var (
+	volumeGroupKind        = "VolumeGroup"
+	volumeGroupClassKind   = "VolumeGroupClass"
+	volumeGroupContentKind = "VolumeGroupContent"
+)
+
+// SetupConversionWebhookWithManager serves the conversion of volumeGroups and volumeGroupContents
+// between v1 and the v2 storage version, the CRDs call it for every v1 request.
+func SetupConversionWebhookWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&volumegroupv1.VolumeGroup{}).
+		Complete()
+	if err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&volumegroupv1.VolumeGroupContent{}).
+		Complete()
+}
+
+func SetupWebhooksWithManager(mgr ctrl.Manager, cfg *config.DriverConfig) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&volumegroupv1.VolumeGroup{}).
+		WithDefaulter(&VolumeGroupDefaulter{
+			Client:  mgr.GetClient(),
+			Log:     ctrl.Log.WithName("webhooks").WithName(volumeGroupKind),
+			Drivers: cfg.GetDriverNames(),
+		}).
+		WithValidator(&VolumeGroupValidator{Client: mgr.GetClient()}).
+		Complete()
+	if err != nil {
+		return err
+	}
+	err = ctrl.NewWebhookManagedBy(mgr).
+		For(&volumegroupv1.VolumeGroupClass{}).
+		WithValidator(&VolumeGroupClassValidator{}).
+		Complete()
+	if err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&volumegroupv1.VolumeGroupContent{}).
+		WithValidator(&VolumeGroupContentValidator{}).
+		Complete()
+}
+
+func toInvalidError(kind, name string, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: volumegroupv1.GroupVersion.Group, Kind: kind}, name, allErrs)
+}
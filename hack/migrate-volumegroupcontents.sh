@@ -0,0 +1,74 @@
+#!/bin/bash -e
+
+#
+# Copyright 2022 IBM Corp.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Migrates namespaced volumeGroupContents to the cluster scoped volumeGroupContent CRD.
+# The scope of a CRD is immutable, so the objects are exported, the CRD is replaced and
+# the objects are created again without a namespace. Stop the operator before running it,
+# deleting the volumeGroupContents does not touch the volume groups on the storage.
+# The CRD is taken from the kustomization the operator is deployed with, only it has the
+# conversion webhook and its CA. The saved v1 objects are rewritten to v2, the storage version,
+# so they are created without the conversion webhook and their members are kept.
+# Requires kubectl, kustomize and jq.
+
+crd_name=volumegroupcontents.csi.ibm.com
+backup_file=${1:-volumegroupcontents-backup.json}
+kustomize_dir=${2:-config/default}
+
+# to_v2 converts a v1 volumeGroupContent the way the conversion webhook does, persistentVolumes
+# become member references.
+to_v2='.apiVersion = "csi.ibm.com/v2"
+    | .status = ((.status // {})
+        | .members = [(.pvList // [])[] | {
+            name: (.spec.claimRef.name // ""),
+            namespace: (.spec.claimRef.namespace // ""),
+            uid: .spec.claimRef.uid,
+            persistentVolumeName: .metadata.name,
+            volumeHandle: .spec.csi.volumeHandle} | with_entries(select(.value != null))]
+        | del(.pvList)
+        | if .members == [] then del(.members) else . end)'
+
+scope=$(kubectl get crd ${crd_name} -o jsonpath='{.spec.scope}')
+if [ "${scope}" != "Namespaced" ]; then
+    echo "${crd_name} is already ${scope} scoped, nothing to migrate"
+    exit 0
+fi
+
+kubectl get volumegroupcontents --all-namespaces -o json > ${backup_file}
+echo "volumeGroupContents are saved in ${backup_file}"
+
+duplicated_names=$(jq -r '.items[].metadata.name' ${backup_file} | sort | uniq -d)
+if [ -n "${duplicated_names}" ]; then
+    echo "volumeGroupContent names must be unique across namespaces, rename these before migrating:"
+    echo "${duplicated_names}"
+    exit 1
+fi
+
+for vgc in $(jq -r '.items[] | .metadata.namespace + "/" + .metadata.name' ${backup_file}); do
+    kubectl patch volumegroupcontent -n ${vgc%%/*} ${vgc##*/} --type merge -p '{"metadata":{"finalizers":null}}'
+done
+kubectl delete crd ${crd_name}
+kustomize build ${kustomize_dir} | awk -v name="${crd_name}" 'BEGIN { RS = "\n---\n" }
+    /kind: CustomResourceDefinition/ && $0 ~ "\n  name: " name "\n" { print }' | kubectl apply -f -
+kubectl wait --for condition=established --timeout=60s crd/${crd_name}
+
+jq -c ".items[] | ${to_v2}" ${backup_file} | while read -r vgc; do
+    echo "${vgc}" | jq 'del(.metadata.namespace, .metadata.uid, .metadata.resourceVersion,
+        .metadata.creationTimestamp, .metadata.managedFields, .status)' | kubectl create -f -
+    name=$(echo "${vgc}" | jq -r '.metadata.name')
+    status=$(echo "${vgc}" | jq -c '{status: (.status // {})}')
+    kubectl patch volumegroupcontents.v2.csi.ibm.com ${name} --subresource status --type merge -p "${status}"
+done
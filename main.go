@@ -17,29 +17,45 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/IBM/csi-volume-group-operator/controllers/garbagecollector"
 	"github.com/IBM/csi-volume-group-operator/controllers/persistentvolumeclaim"
+	"github.com/IBM/csi-volume-group-operator/controllers/utils"
+	"github.com/IBM/csi-volume-group-operator/controllers/volumegroupcontent"
+	"github.com/IBM/csi-volume-group-operator/controllers/webhooks"
 	grpcClient "github.com/IBM/csi-volume-group-operator/pkg/client"
 	"github.com/IBM/csi-volume-group-operator/pkg/config"
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"github.com/IBM/csi-volume-group-operator/pkg/tracing"
 	"github.com/go-logr/logr"
 
 	uberzap "go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	volumegroupv1 "github.com/IBM/csi-volume-group-operator/api/v1"
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
 	"github.com/IBM/csi-volume-group-operator/controllers"
 	//+kubebuilder:scaffold:imports
 )
@@ -47,21 +63,57 @@ import (
 const (
 	// defaultTimeout is default timeout for RPC call.
 	defaultTimeout = time.Minute
+	// defaultResyncPeriod is default period for comparing volume group membership with the storage.
+	defaultResyncPeriod = 10 * time.Minute
+	// defaultOrphanedVolumeGroupsInterval is default period for looking for orphaned volume groups on the storage.
+	defaultOrphanedVolumeGroupsInterval = time.Hour
+	// defaultRetryIntervalStart is default initial backoff of failed driver requests.
+	defaultRetryIntervalStart = time.Second
+	// defaultRetryIntervalMax is default maximum backoff of failed driver requests.
+	defaultRetryIntervalMax = 5 * time.Minute
+	// defaultMembershipBatchWindow is default period for gathering the membership changes of a volume group.
+	defaultMembershipBatchWindow = time.Second
+	// defaultLeaseDuration is default duration that non-leader candidates wait before acquiring the leadership.
+	defaultLeaseDuration = 15 * time.Second
+	// defaultRenewDeadline is default duration that the leader retries refreshing the leadership before giving it up.
+	defaultRenewDeadline = 10 * time.Second
+	// defaultRetryPeriod is default duration between leader election attempts.
+	defaultRetryPeriod = 2 * time.Second
+	// leaderCheckName is the readiness check that fails on the replicas that are not the leader.
+	leaderCheckName = "leader"
+	// driverCheckCacheDuration is how long the result of probing the driver is reused by the readiness check.
+	driverCheckCacheDuration = 10 * time.Second
+	// driverCheckPrefix is the prefix of the readiness check of every driver, followed by the driver name.
+	driverCheckPrefix = "driver-"
 )
 
 var (
-	scheme        = runtime.NewScheme()
-	setupLog      = ctrl.Log.WithName("setup")
-	pvcController = "PersistentVolumeClaimController"
+	scheme         = runtime.NewScheme()
+	setupLog       = ctrl.Log.WithName("setup")
+	pvcController  = "PersistentVolumeClaimController"
+	vgcController  = "VolumeGroupContentController"
+	gcName         = "OrphanedVolumeGroupsCollector"
+	enableWebhooks bool
+
+	probeAddr               string
+	enableLeaderElection    bool
+	leaderElectionNamespace string
+	leaseDuration           time.Duration
+	renewDeadline           time.Duration
+	retryPeriod             time.Duration
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(volumegroupv1.AddToScheme(scheme))
+	utilruntime.Must(volumegroupv2.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
 func main() {
 	opts := zap.Options{
 		ZapOpts: []uberzap.Option{
@@ -81,34 +133,97 @@ func main() {
 	err := cfg.Validate()
 	exitWithError(err, "error in driver configuration")
 
+	shutdownTracing, err := tracing.Setup(cfg.TracingEndpoint, cfg.TracingFile)
+	exitWithError(err, "unable to set up tracing")
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
-		Port:   9443,
+		Scheme:                  scheme,
+		Port:                    9443,
+		NewClient:               newTracedClient,
+		HealthProbeBindAddress:  probeAddr,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        getLeaderElectionID(cfg.GetDriverNames()),
+		LeaderElectionNamespace: leaderElectionNamespace,
+		LeaseDuration:           &leaseDuration,
+		RenewDeadline:           &renewDeadline,
+		RetryPeriod:             &retryPeriod,
+		// main exits right after the manager stops, so the lease can be released for the next leader.
+		LeaderElectionReleaseOnCancel: true,
 	})
 	exitWithError(err, "unable to start manager")
 
+	ctx := ctrl.SetupSignalHandler()
+	err = utils.SetupIndexers(ctx, mgr.GetFieldIndexer())
+	exitWithError(err, "unable to set up field indexers")
+
 	log := ctrl.Log.WithName("controllers").WithName("VolumeGroup")
-	grpcClientInstance, err := getControllerGrpcClient(cfg, log)
+	grpcClients, err := getControllerGrpcClients(cfg, log)
 	exitWithError(err, "failed to get controller GRPC client")
 
+	// The drivers are probed once this replica is the leader, the other replicas never call them.
+	err = mgr.Add(manager.RunnableFunc(func(context.Context) error {
+		return probeDrivers(cfg, grpcClients, log)
+	}))
+	exitWithError(err, "unable to set up driver probe")
+
 	err = (&controllers.VolumeGroupReconciler{
 		Client:       mgr.GetClient(),
+		APIReader:    mgr.GetAPIReader(),
 		Log:          log,
 		Scheme:       mgr.GetScheme(),
 		DriverConfig: cfg,
-		GRPCClient:   grpcClientInstance,
-	}).SetupWithManager(mgr, cfg)
+		GRPCClients:  grpcClients,
+	}).SetupWithManager(ctx, mgr, cfg)
 	exitWithError(err, "unable to create controller  with controller VolumeGroup")
 
 	err = (&persistentvolumeclaim.PersistentVolumeClaimReconciler{
 		Client:       mgr.GetClient(),
+		APIReader:    mgr.GetAPIReader(),
 		Scheme:       mgr.GetScheme(),
 		Log:          ctrl.Log.WithName(pvcController),
 		DriverConfig: cfg,
-		GRPCClient:   grpcClientInstance,
+		GRPCClients:  grpcClients,
 	}).SetupWithManager(mgr, cfg)
 	exitWithError(err, messages.UnableToCreatePVCController)
 
+	err = (&volumegroupcontent.VolumeGroupContentReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Log:          ctrl.Log.WithName(vgcController),
+		DriverConfig: cfg,
+	}).SetupWithManager(mgr)
+	exitWithError(err, messages.UnableToCreateVGCController)
+
+	err = (&garbagecollector.OrphanedVolumeGroupsCollector{
+		Reader:       mgr.GetAPIReader(),
+		Client:       mgr.GetClient(),
+		Log:          ctrl.Log.WithName(gcName),
+		DriverConfig: cfg,
+		GRPCClients:  grpcClients,
+	}).SetupWithManager(mgr)
+	exitWithError(err, messages.UnableToCreateOrphanedVGCollector)
+
+	err = metrics.RegisterVolumeGroupCollector(func() ([]volumegroupv2.VolumeGroup, error) {
+		vgs := []volumegroupv2.VolumeGroup{}
+		for _, driver := range cfg.GetDriverNames() {
+			vgList, err := utils.GetVGList(context.Background(), logr.Discard(), mgr.GetClient(), driver)
+			if err != nil {
+				return nil, err
+			}
+			vgs = append(vgs, vgList.Items...)
+		}
+		return vgs, nil
+	})
+	exitWithError(err, "unable to register volume group metrics")
+
+	err = webhooks.SetupConversionWebhookWithManager(mgr)
+	exitWithError(err, "unable to create conversion webhook")
+
+	if enableWebhooks {
+		err = webhooks.SetupWebhooksWithManager(mgr, cfg)
+		exitWithError(err, "unable to create webhooks")
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	err = mgr.AddHealthzCheck("healthz", healthz.Ping)
@@ -117,8 +232,20 @@ func main() {
 	err = mgr.AddReadyzCheck("readyz", healthz.Ping)
 	exitWithError(err, "unable to set up ready check")
 
+	err = mgr.AddReadyzCheck(leaderCheckName, leaderCheck(mgr))
+	exitWithError(err, "unable to set up leader check")
+
+	for _, driver := range cfg.GetDriverNames() {
+		driverChecker := grpcClient.NewDriverChecker(grpcClients[driver], driver, mgr.Elected(), driverCheckCacheDuration)
+		err = mgr.AddReadyzCheck(driverCheckPrefix+driver, driverChecker.Check)
+		exitWithError(err, "unable to set up driver check")
+	}
+
 	setupLog.Info("starting manager")
-	err = mgr.Start(ctrl.SetupSignalHandler())
+	err = mgr.Start(ctx)
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		setupLog.Error(shutdownErr, "failed to flush traces")
+	}
 	exitWithError(err, "problem running manager")
 
 }
@@ -126,25 +253,87 @@ func main() {
 func defineFlags(cfg *config.DriverConfig) {
 	flag.StringVar(&cfg.DriverName, "driver-name", "", "The CSI driver name.")
 	flag.StringVar(&cfg.DriverEndpoint, "csi-address", "/run/csi/socket", "Address of the CSI driver socket.")
+	flag.Var(config.DriversFlag(cfg.Drivers), "driver",
+		"A CSI driver to serve as name=address, it may be repeated to serve several drivers besides driver-name.")
 	flag.DurationVar(&cfg.RPCTimeout, "rpc-timeout", defaultTimeout, "The timeout for RPCs to the CSI driver.")
 	flag.StringVar(&cfg.MultipleVGsToPVC, "multiple-vgs-to-pvc", "true", "Can PVC be assigned to multiple VolumeGroups.")
-	flag.StringVar(&cfg.DisableDeletePvcs, "disable-delete-pvcs", "false", "Does volumeGroup deletion delete all its PVCs.")
+	flag.StringVar(&cfg.DisableDeletePvcs, "disable-delete-pvcs", "true", "Does volumeGroup deletion keep its PVCs, used when its volumeGroupClass does not set persistentVolumeClaimDeletionPolicy. Set to false to delete them.")
+	flag.DurationVar(&cfg.ResyncPeriod, "resync-period", defaultResyncPeriod, "How often the membership of bound volumeGroups is compared with the storage, 0 disables it.")
+	flag.StringVar(&cfg.OrphanedVolumeGroupsPolicy, "orphaned-volume-groups-policy", config.OrphanedVolumeGroupsReport,
+		"What to do with volume groups on the storage whose volumeGroup no longer exists: Disabled, Report or Delete.")
+	flag.DurationVar(&cfg.OrphanedVolumeGroupsInterval, "orphaned-volume-groups-interval", defaultOrphanedVolumeGroupsInterval,
+		"How often the storage is checked for orphaned volume groups.")
+	flag.DurationVar(&cfg.RetryIntervalStart, "retry-interval-start", defaultRetryIntervalStart,
+		"Initial retry interval of failed driver requests, it doubles with each failure up to retry-interval-max.")
+	flag.DurationVar(&cfg.RetryIntervalMax, "retry-interval-max", defaultRetryIntervalMax, "Maximum retry interval of failed driver requests.")
+	flag.DurationVar(&cfg.MembershipBatchWindow, "membership-batch-window", defaultMembershipBatchWindow,
+		"How long persistentVolumeClaim changes of a volumeGroup are gathered before its membership is modified with a single driver request.")
+	flag.StringVar(&cfg.TracingEndpoint, "tracing-endpoint", "",
+		"OTLP/HTTP traces URL, e.g. http://otel-collector:4318/v1/traces, spans are sent there as JSON. Tracing is disabled when it and tracing-file are empty.")
+	flag.StringVar(&cfg.TracingFile, "tracing-file", "", "File the spans are appended to as OTLP JSON lines.")
+	flag.BoolVar(&cfg.ReconnectOnConnectionLoss, "reconnect-on-connection-loss", false,
+		"Reconnect to the CSI driver when the connection is lost, reconciles are requeued until it is back. By default the operator exits.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Serve the admission webhooks. The conversion webhook is always served and requires a serving certificate.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the health probe endpoint binds to.")
+	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
+		"Enable leader election, only the leader reconciles and calls the CSI driver so several replicas can run.")
+	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "",
+		"Namespace of the leader election lease, defaults to the namespace of the operator.")
+	flag.DurationVar(&leaseDuration, "leader-election-lease-duration", defaultLeaseDuration,
+		"Duration that non-leader candidates wait before forcing to acquire the leadership.")
+	flag.DurationVar(&renewDeadline, "leader-election-renew-deadline", defaultRenewDeadline,
+		"Duration that the leader retries refreshing the leadership before giving it up.")
+	flag.DurationVar(&retryPeriod, "leader-election-retry-period", defaultRetryPeriod,
+		"Duration the candidates wait between tries of acquiring or renewing the leadership.")
 }
 
-func getControllerGrpcClient(cfg *config.DriverConfig, log logr.Logger) (*grpcClient.Client, error) {
-	grpcClientInstance, err := grpcClient.New(cfg.DriverEndpoint, cfg.RPCTimeout)
+func newTracedClient(cache cache.Cache, restConfig *rest.Config, options client.Options, uncachedObjects ...client.Object) (client.Client, error) {
+	c, err := cluster.DefaultNewClient(cache, restConfig, options, uncachedObjects...)
 	if err != nil {
-		log.Error(err, "failed to create GRPC Client", "Endpoint", cfg.DriverEndpoint, "GRPC Timeout", cfg.RPCTimeout)
-
 		return nil, err
 	}
-	err = grpcClientInstance.Probe()
-	if err != nil {
-		log.Error(err, "failed to connect to driver", "Endpoint", cfg.DriverEndpoint, "GRPC Timeout", cfg.RPCTimeout)
+	return tracing.NewClient(c), nil
+}
 
-		return nil, err
+func getControllerGrpcClients(cfg *config.DriverConfig, log logr.Logger) (map[string]*grpcClient.Client, error) {
+	grpcClients := map[string]*grpcClient.Client{}
+	for driver, endpoint := range cfg.GetDrivers() {
+		grpcClientInstance, err := grpcClient.New(endpoint, cfg.RPCTimeout, cfg.ReconnectOnConnectionLoss)
+		if err != nil {
+			log.Error(err, "failed to create GRPC Client", "Driver", driver, "Endpoint", endpoint, "GRPC Timeout", cfg.RPCTimeout)
+
+			return nil, err
+		}
+		grpcClients[driver] = grpcClientInstance
+	}
+	return grpcClients, nil
+}
+
+func probeDrivers(cfg *config.DriverConfig, grpcClients map[string]*grpcClient.Client, log logr.Logger) error {
+	for driver, endpoint := range cfg.GetDrivers() {
+		err := grpcClients[driver].Probe()
+		if err != nil {
+			log.Error(err, "failed to connect to driver", "Driver", driver, "Endpoint", endpoint, "GRPC Timeout", cfg.RPCTimeout)
+			return err
+		}
+	}
+	return nil
+}
+
+// getLeaderElectionID returns the lease name, one per set of drivers so operators of different drivers do not block each other.
+func getLeaderElectionID(driverNames []string) string {
+	return strings.ToLower(strings.Join(driverNames, "-")) + "-volume-group-operator"
+}
+
+func leaderCheck(mgr ctrl.Manager) healthz.Checker {
+	return func(_ *http.Request) error {
+		select {
+		case <-mgr.Elected():
+			return nil
+		default:
+			return errors.New("not the leader")
+		}
 	}
-	return grpcClientInstance, err
 }
 
 func exitWithError(err error, msg string) {
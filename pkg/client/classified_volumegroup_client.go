@@ -0,0 +1,81 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// terminalCodes are the codes of driver errors that are caused by the request, like an invalid
+// parameter in the volumeGroupClass. All other codes, like Unavailable, DeadlineExceeded and
+// Aborted, are transient and the request is retried with backoff.
+var terminalCodes = map[codes.Code]bool{
+	codes.InvalidArgument:  true,
+	codes.AlreadyExists:    true,
+	codes.PermissionDenied: true,
+	codes.Unauthenticated:  true,
+	codes.OutOfRange:       true,
+	codes.Unimplemented:    true,
+}
+
+// classifiedVolumeGroupClient returns the gRPC errors of the driver as DriverErrors, so the
+// controllers can tell terminal errors from transient ones.
+type classifiedVolumeGroupClient struct {
+	volumeGroup VolumeGroup
+}
+
+func newClassifiedVolumeGroupClient(volumeGroup VolumeGroup) VolumeGroup {
+	return &classifiedVolumeGroupClient{volumeGroup: volumeGroup}
+}
+
+func classifyError(err error) error {
+	s, ok := status.FromError(err)
+	if err == nil || !ok {
+		return err
+	}
+	return &vgerrors.DriverError{Err: err, Terminal: terminalCodes[s.Code()]}
+}
+
+func (c *classifiedVolumeGroupClient) CreateVolumeGroup(ctx context.Context, name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error) {
+	resp, err := c.volumeGroup.CreateVolumeGroup(ctx, name, secrets, parameters)
+	return resp, classifyError(err)
+}
+
+func (c *classifiedVolumeGroupClient) DeleteVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
+	resp, err := c.volumeGroup.DeleteVolumeGroup(ctx, volumeGroupId, secrets)
+	return resp, classifyError(err)
+}
+
+func (c *classifiedVolumeGroupClient) ModifyVolumeGroupMembership(ctx context.Context, volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
+	resp, err := c.volumeGroup.ModifyVolumeGroupMembership(ctx, volumeGroupId, volumeIds, secrets)
+	return resp, classifyError(err)
+}
+
+func (c *classifiedVolumeGroupClient) ControllerGetVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.ControllerGetVolumeGroupResponse, error) {
+	resp, err := c.volumeGroup.ControllerGetVolumeGroup(ctx, volumeGroupId, secrets)
+	return resp, classifyError(err)
+}
+
+func (c *classifiedVolumeGroupClient) ListVolumeGroups(ctx context.Context, maxEntries int32, startingToken string, secrets map[string]string) (*csi.ListVolumeGroupsResponse, error) {
+	resp, err := c.volumeGroup.ListVolumeGroups(ctx, maxEntries, startingToken, secrets)
+	return resp, classifyError(err)
+}
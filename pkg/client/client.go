@@ -24,6 +24,7 @@ import (
 	"github.com/kubernetes-csi/csi-lib-utils/metrics"
 	"github.com/kubernetes-csi/csi-lib-utils/rpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 )
 
 type Client struct {
@@ -31,13 +32,18 @@ type Client struct {
 	Timeout time.Duration
 }
 
-func connect(address string) (*grpc.ClientConn, error) {
-	return connection.Connect(address, metrics.NewCSIMetricsManager(""), connection.OnConnectionLoss(connection.ExitOnConnectionLoss()))
+// connect exits the process when the connection to the driver is lost, unless reconnect is set.
+func connect(address string, reconnect bool) (*grpc.ClientConn, error) {
+	options := []connection.Option{}
+	if !reconnect {
+		options = append(options, connection.OnConnectionLoss(connection.ExitOnConnectionLoss()))
+	}
+	return connection.Connect(address, metrics.NewCSIMetricsManager(""), options...)
 }
 
-func New(address string, timeout time.Duration) (*Client, error) {
+func New(address string, timeout time.Duration, reconnect bool) (*Client, error) {
 	c := &Client{}
-	cc, err := connect(address)
+	cc, err := connect(address, reconnect)
 	if err != nil {
 		return c, err
 	}
@@ -51,6 +57,13 @@ func (c *Client) Probe() error {
 	return rpc.ProbeForever(c.Client, c.Timeout)
 }
 
+// IsConnected reports whether requests can be sent to the driver, it is false while the connection
+// is being reestablished.
+func (c *Client) IsConnected() bool {
+	state := c.Client.GetState()
+	return state == connectivity.Ready || state == connectivity.Idle
+}
+
 func (c *Client) GetDriverName() (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
 	defer cancel()
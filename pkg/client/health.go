@@ -0,0 +1,94 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	"github.com/kubernetes-csi/csi-lib-utils/rpc"
+)
+
+// DriverChecker is a health check of the CSI driver. The leader probes the driver and reuses the
+// result for cacheDuration, so frequent probes of the pod do not load the driver. The other
+// replicas never call the driver and only check the connection.
+type DriverChecker struct {
+	client        *Client
+	driverName    string
+	elected       <-chan struct{}
+	cacheDuration time.Duration
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+func NewDriverChecker(client *Client, driverName string, elected <-chan struct{}, cacheDuration time.Duration) *DriverChecker {
+	return &DriverChecker{client: client, driverName: driverName, elected: elected, cacheDuration: cacheDuration}
+}
+
+// Check implements healthz.Checker.
+func (c *DriverChecker) Check(_ *http.Request) error {
+	err := c.check()
+	metrics.SetDriverReady(c.driverName, err == nil)
+	return err
+}
+
+func (c *DriverChecker) check() error {
+	select {
+	case <-c.elected:
+	default:
+		if !c.client.IsConnected() {
+			return fmt.Errorf("not connected to %s CSI driver", c.driverName)
+		}
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.checkedAt) < c.cacheDuration {
+		return c.err
+	}
+	c.err = c.probe()
+	c.checkedAt = time.Now()
+	return c.err
+}
+
+func (c *DriverChecker) probe() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.client.Timeout)
+	defer cancel()
+
+	ready, err := rpc.Probe(ctx, c.client.Client)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		return fmt.Errorf("%s CSI driver is not ready", c.driverName)
+	}
+	driverName, err := rpc.GetDriverName(ctx, c.client.Client)
+	if err != nil {
+		return err
+	}
+	if driverName != c.driverName {
+		return fmt.Errorf("the CSI driver is %s instead of %s", driverName, c.driverName)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/csi-volume-group-operator/pkg/metrics"
+	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
+)
+
+// instrumentedVolumeGroupClient records the latency and the errors of the requests to the driver.
+type instrumentedVolumeGroupClient struct {
+	driver      string
+	volumeGroup VolumeGroup
+}
+
+func newInstrumentedVolumeGroupClient(driver string, volumeGroup VolumeGroup) VolumeGroup {
+	return &instrumentedVolumeGroupClient{driver: driver, volumeGroup: volumeGroup}
+}
+
+func (c *instrumentedVolumeGroupClient) CreateVolumeGroup(ctx context.Context, name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error) {
+	start := time.Now()
+	resp, err := c.volumeGroup.CreateVolumeGroup(ctx, name, secrets, parameters)
+	metrics.ObserveDriverRPC(c.driver, "CreateVolumeGroup", start, err)
+	return resp, err
+}
+
+func (c *instrumentedVolumeGroupClient) DeleteVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
+	start := time.Now()
+	resp, err := c.volumeGroup.DeleteVolumeGroup(ctx, volumeGroupId, secrets)
+	metrics.ObserveDriverRPC(c.driver, "DeleteVolumeGroup", start, err)
+	return resp, err
+}
+
+func (c *instrumentedVolumeGroupClient) ModifyVolumeGroupMembership(ctx context.Context, volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
+	start := time.Now()
+	resp, err := c.volumeGroup.ModifyVolumeGroupMembership(ctx, volumeGroupId, volumeIds, secrets)
+	metrics.ObserveDriverRPC(c.driver, "ModifyVolumeGroupMembership", start, err)
+	return resp, err
+}
+
+func (c *instrumentedVolumeGroupClient) ControllerGetVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.ControllerGetVolumeGroupResponse, error) {
+	start := time.Now()
+	resp, err := c.volumeGroup.ControllerGetVolumeGroup(ctx, volumeGroupId, secrets)
+	metrics.ObserveDriverRPC(c.driver, "ControllerGetVolumeGroup", start, err)
+	return resp, err
+}
+
+func (c *instrumentedVolumeGroupClient) ListVolumeGroups(ctx context.Context, maxEntries int32, startingToken string, secrets map[string]string) (*csi.ListVolumeGroupsResponse, error) {
+	start := time.Now()
+	resp, err := c.volumeGroup.ListVolumeGroups(ctx, maxEntries, startingToken, secrets)
+	metrics.ObserveDriverRPC(c.driver, "ListVolumeGroups", start, err)
+	return resp, err
+}
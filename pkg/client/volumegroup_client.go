@@ -20,6 +20,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/IBM/csi-volume-group-operator/pkg/tracing"
 	csi "github.com/IBM/csi-volume-group/lib/go/volumegroup"
 	"google.golang.org/grpc"
 )
@@ -30,52 +31,101 @@ type volumeGroupClient struct {
 }
 
 type VolumeGroup interface {
-	CreateVolumeGroup(name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error)
-	DeleteVolumeGroup(volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error)
-	ModifyVolumeGroupMembership(volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error)
+	CreateVolumeGroup(ctx context.Context, name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error)
+	DeleteVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error)
+	ModifyVolumeGroupMembership(ctx context.Context, volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error)
+	ControllerGetVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.ControllerGetVolumeGroupResponse, error)
+	ListVolumeGroups(ctx context.Context, maxEntries int32, startingToken string, secrets map[string]string) (*csi.ListVolumeGroupsResponse, error)
 }
 
-func NewVolumeGroupClient(cc *grpc.ClientConn, timeout time.Duration) VolumeGroup {
-	return &volumeGroupClient{client: csi.NewControllerClient(cc), timeout: timeout}
+func NewVolumeGroupClient(driver string, cc *grpc.ClientConn, timeout time.Duration) VolumeGroup {
+	vgClient := &volumeGroupClient{client: csi.NewControllerClient(cc), timeout: timeout}
+	return newClassifiedVolumeGroupClient(newInstrumentedVolumeGroupClient(driver, vgClient))
 }
 
-func (rc *volumeGroupClient) CreateVolumeGroup(name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error) {
+// NewVolumeGroupClients returns the VolumeGroup client of every driver by driver name.
+func NewVolumeGroupClients(clients map[string]*Client, timeout time.Duration) map[string]VolumeGroup {
+	vgClients := map[string]VolumeGroup{}
+	for driver, client := range clients {
+		vgClients[driver] = NewVolumeGroupClient(driver, client.Client, timeout)
+	}
+	return vgClients
+}
+
+func (rc *volumeGroupClient) CreateVolumeGroup(ctx context.Context, name string, secrets, parameters map[string]string) (*csi.CreateVolumeGroupResponse, error) {
 	req := &csi.CreateVolumeGroupRequest{
 		Name:       name,
 		Parameters: parameters,
 		Secrets:    secrets,
 	}
 
-	createCtx, cancel := context.WithTimeout(context.Background(), rc.timeout)
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
 	defer cancel()
+	createCtx, span := tracing.StartDriverSpan(createCtx, "CreateVolumeGroup")
 	resp, err := rc.client.CreateVolumeGroup(createCtx, req)
+	tracing.EndSpan(span, err)
 
 	return resp, err
 }
 
-func (rc *volumeGroupClient) DeleteVolumeGroup(volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
+func (rc *volumeGroupClient) DeleteVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.DeleteVolumeGroupResponse, error) {
 	req := &csi.DeleteVolumeGroupRequest{
 		VolumeGroupId: volumeGroupId,
 		Secrets:       secrets,
 	}
 
-	createCtx, cancel := context.WithTimeout(context.Background(), rc.timeout)
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
 	defer cancel()
+	createCtx, span := tracing.StartDriverSpan(createCtx, "DeleteVolumeGroup")
 	resp, err := rc.client.DeleteVolumeGroup(createCtx, req)
+	tracing.EndSpan(span, err)
 
 	return resp, err
 }
 
-func (rc *volumeGroupClient) ModifyVolumeGroupMembership(volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
+func (rc *volumeGroupClient) ModifyVolumeGroupMembership(ctx context.Context, volumeGroupId string, volumeIds []string, secrets map[string]string) (*csi.ModifyVolumeGroupMembershipResponse, error) {
 	req := &csi.ModifyVolumeGroupMembershipRequest{
 		VolumeGroupId: volumeGroupId,
 		VolumeIds:     volumeIds,
 		Secrets:       secrets,
 	}
 
-	createCtx, cancel := context.WithTimeout(context.Background(), rc.timeout)
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
 	defer cancel()
+	createCtx, span := tracing.StartDriverSpan(createCtx, "ModifyVolumeGroupMembership")
 	resp, err := rc.client.ModifyVolumeGroupMembership(createCtx, req)
+	tracing.EndSpan(span, err)
+
+	return resp, err
+}
+
+func (rc *volumeGroupClient) ControllerGetVolumeGroup(ctx context.Context, volumeGroupId string, secrets map[string]string) (*csi.ControllerGetVolumeGroupResponse, error) {
+	req := &csi.ControllerGetVolumeGroupRequest{
+		VolumeGroupId: volumeGroupId,
+		Secrets:       secrets,
+	}
+
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
+	defer cancel()
+	createCtx, span := tracing.StartDriverSpan(createCtx, "ControllerGetVolumeGroup")
+	resp, err := rc.client.ControllerGetVolumeGroup(createCtx, req)
+	tracing.EndSpan(span, err)
+
+	return resp, err
+}
+
+func (rc *volumeGroupClient) ListVolumeGroups(ctx context.Context, maxEntries int32, startingToken string, secrets map[string]string) (*csi.ListVolumeGroupsResponse, error) {
+	req := &csi.ListVolumeGroupsRequest{
+		MaxEntries:    maxEntries,
+		StartingToken: startingToken,
+		Secrets:       secrets,
+	}
+
+	createCtx, cancel := context.WithTimeout(ctx, rc.timeout)
+	defer cancel()
+	createCtx, span := tracing.StartDriverSpan(createCtx, "ListVolumeGroups")
+	resp, err := rc.client.ListVolumeGroups(createCtx, req)
+	tracing.EndSpan(span, err)
 
 	return resp, err
 }
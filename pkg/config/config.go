@@ -18,25 +18,105 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"time"
 )
 
+const (
+	// OrphanedVolumeGroupsDisabled turns off the orphaned volume groups collector.
+	OrphanedVolumeGroupsDisabled = "Disabled"
+	// OrphanedVolumeGroupsReport only logs the orphaned volume groups found on the storage.
+	OrphanedVolumeGroupsReport = "Report"
+	// OrphanedVolumeGroupsDelete deletes the orphaned volume groups from the storage.
+	OrphanedVolumeGroupsDelete = "Delete"
+)
+
 type DriverConfig struct {
+	// DriverName and DriverEndpoint configure a single driver, Drivers maps the names of other
+	// drivers to the addresses of their sockets.
 	DriverEndpoint    string
 	DriverName        string
+	Drivers           map[string]string
 	RPCTimeout        time.Duration
 	MultipleVGsToPVC  string
 	DisableDeletePvcs string
+	ResyncPeriod      time.Duration
+
+	OrphanedVolumeGroupsPolicy   string
+	OrphanedVolumeGroupsInterval time.Duration
+
+	RetryIntervalStart time.Duration
+	RetryIntervalMax   time.Duration
+
+	TracingEndpoint string
+	TracingFile     string
+
+	ReconnectOnConnectionLoss bool
+
+	// MembershipBatchWindow is how long the membership changes of a volumeGroup are gathered before
+	// they are sent to the driver in a single request.
+	MembershipBatchWindow time.Duration
 }
 
 func NewDriverConfig() *DriverConfig {
-	return &DriverConfig{}
+	return &DriverConfig{Drivers: map[string]string{}}
+}
+
+// GetDrivers returns the address of the socket of every driver the operator serves by driver name.
+func (cfg *DriverConfig) GetDrivers() map[string]string {
+	drivers := map[string]string{}
+	for name, endpoint := range cfg.Drivers {
+		drivers[name] = endpoint
+	}
+	if cfg.DriverName != "" {
+		drivers[cfg.DriverName] = cfg.DriverEndpoint
+	}
+	return drivers
+}
+
+func (cfg *DriverConfig) GetDriverNames() []string {
+	names := []string{}
+	for name := range cfg.GetDrivers() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (cfg *DriverConfig) IsDriverServed(driver string) bool {
+	_, ok := cfg.GetDrivers()[driver]
+	return ok
 }
 
 func (cfg *DriverConfig) Validate() error {
 
-	if cfg.DriverName == "" {
-		return errors.New("driverName is empty")
+	if cfg.DriverName == "" && len(cfg.Drivers) == 0 {
+		return errors.New("driverName is empty and no drivers are set")
+	}
+	if endpoint, ok := cfg.Drivers[cfg.DriverName]; ok && endpoint != cfg.DriverEndpoint {
+		return fmt.Errorf("%s driver is set with two different endpoints", cfg.DriverName)
+	}
+	for name, endpoint := range cfg.GetDrivers() {
+		if endpoint == "" {
+			return fmt.Errorf("endpoint of %s driver is empty", name)
+		}
+	}
+
+	switch cfg.OrphanedVolumeGroupsPolicy {
+	case OrphanedVolumeGroupsDisabled, OrphanedVolumeGroupsReport, OrphanedVolumeGroupsDelete:
+	default:
+		return fmt.Errorf("orphanedVolumeGroupsPolicy must be one of %s, %s and %s", OrphanedVolumeGroupsDisabled,
+			OrphanedVolumeGroupsReport, OrphanedVolumeGroupsDelete)
+	}
+	if cfg.OrphanedVolumeGroupsPolicy != OrphanedVolumeGroupsDisabled && cfg.OrphanedVolumeGroupsInterval <= 0 {
+		return errors.New("orphanedVolumeGroupsInterval must be positive")
+	}
+	if cfg.RetryIntervalStart <= 0 || cfg.RetryIntervalMax < cfg.RetryIntervalStart {
+		return errors.New("retryIntervalStart must be positive and not greater than retryIntervalMax")
+	}
+	if cfg.MembershipBatchWindow < 0 {
+		return errors.New("membershipBatchWindow must not be negative")
 	}
 
 	return nil
@@ -0,0 +1,47 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DriversFlag is a flag.Value that adds a driver for every name=address value.
+type DriversFlag map[string]string
+
+func (f DriversFlag) String() string {
+	drivers := []string{}
+	for name, endpoint := range f {
+		drivers = append(drivers, name+"="+endpoint)
+	}
+	sort.Strings(drivers)
+	return strings.Join(drivers, ",")
+}
+
+func (f DriversFlag) Set(value string) error {
+	name, endpoint, ok := strings.Cut(value, "=")
+	if !ok || name == "" || endpoint == "" {
+		return fmt.Errorf("%s is not in the name=address format", value)
+	}
+	if _, ok := f[name]; ok {
+		return fmt.Errorf("%s driver is set more than once", name)
+	}
+	f[name] = endpoint
+	return nil
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package errors
 
 import (
+	goerrors "errors"
 	"fmt"
 
 	"github.com/IBM/csi-volume-group-operator/pkg/messages"
+	"google.golang.org/grpc/status"
 )
 
 type MatchingLabelsAndLabelSelectorError struct {
@@ -39,3 +41,79 @@ type PersistentVolumeDoesNotExist struct {
 func (e *PersistentVolumeDoesNotExist) Error() string {
 	return fmt.Sprintf(messages.PersistentVolumeDoesNotExist, e.PVName, e.PVNamespace, e.ErrorMessage)
 }
+
+// PersistentVolumeIsNotCSIError is returned for persistentVolumes without a CSI source, in-tree volumes
+// that were migrated to a CSI driver have no CSI volume handle either.
+type PersistentVolumeIsNotCSIError struct {
+	PVName           string
+	MigratedToDriver string
+}
+
+func (e *PersistentVolumeIsNotCSIError) Error() string {
+	if e.MigratedToDriver != "" {
+		return fmt.Sprintf(messages.PersistentVolumeIsMigratedToCSI, e.PVName, e.MigratedToDriver)
+	}
+	return fmt.Sprintf(messages.PersistentVolumeIsNotCSI, e.PVName)
+}
+
+func IsPersistentVolumeNotCSIError(err error) bool {
+	var notCSIErr *PersistentVolumeIsNotCSIError
+	return goerrors.As(err, &notCSIErr)
+}
+
+type NoDefaultVolumeGroupClassError struct {
+	Driver string
+}
+
+func (e *NoDefaultVolumeGroupClassError) Error() string {
+	return fmt.Sprintf(messages.NoDefaultVolumeGroupClass, e.Driver)
+}
+
+type VolumeGroupContentBoundToOtherVolumeGroupError struct {
+	VGCName     string
+	VGName      string
+	VGNamespace string
+	Reserved    bool
+}
+
+func (e *VolumeGroupContentBoundToOtherVolumeGroupError) Error() string {
+	if e.Reserved {
+		return fmt.Sprintf(messages.VolumeGroupContentReservedForOtherVolumeGroup, e.VGCName, e.VGNamespace, e.VGName)
+	}
+	return fmt.Sprintf(messages.VolumeGroupContentBoundToOtherVolumeGroup, e.VGCName, e.VGNamespace, e.VGName)
+}
+
+type VolumeGroupMembershipDriftError struct {
+	VolumeGroupID       string
+	MissingVolumeIds    []string
+	UnexpectedVolumeIds []string
+}
+
+func (e *VolumeGroupMembershipDriftError) Error() string {
+	return fmt.Sprintf(messages.VolumeGroupMembershipDrift, e.VolumeGroupID, e.MissingVolumeIds, e.UnexpectedVolumeIds)
+}
+
+// DriverError is an error returned by the CSI driver. Terminal errors are caused by the request
+// itself, sending the same request again fails the same way.
+type DriverError struct {
+	Err      error
+	Terminal bool
+}
+
+func (e *DriverError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DriverError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus keeps the gRPC status of the driver error visible to status.FromError.
+func (e *DriverError) GRPCStatus() *status.Status {
+	return status.Convert(e.Err)
+}
+
+func IsTerminalDriverError(err error) bool {
+	var driverErr *DriverError
+	return goerrors.As(err, &driverErr) && driverErr.Terminal
+}
@@ -19,28 +19,20 @@ package messages
 var (
 	ReconcilePersistentVolumeClaim                   = "Reconciling PersistentVolumeClaim"
 	ReconcileVolumeGroup                             = "Reconciling VolumeGroup"
+	ReconcileVolumeGroupContent                      = "Reconciling VolumeGroupContent"
 	RequestName                                      = "Request.Name"
 	RequestNamespace                                 = "Request.Namespace"
 	UnableToCreatePVCController                      = "Unable to create persistentvolumeclaim controller"
+	UnableToCreateVGCController                      = "Unable to create volumegroupcontent controller"
 	PersistentVolumeClaimNotFound                    = "%s/%s persistentVolumeClaim not found"
 	ListVolumeGroups                                 = "Listing volumeGroups"
 	CheckIfPersistentVolumeClaimMatchesVolumeGroup   = "Checking if %s/%s persistentVolumeClaim is matches %s/%s volumeGroup"
 	PersistentVolumeClaimMatchedToVolumeGroup        = "%s/%s persistentVolumeClaim is matched with %s/%s volumeGroup"
 	PersistentVolumeClaimNotMatchedToVolumeGroup     = "%s/%s persistentVolumeClaim is not matched with %s/%s volumeGroup"
-	RemovePersistentVolumeClaimFromVolumeGroup       = "Removing %s/%s persistentVolumeClaim from %s/%s volumeGroup"
-	RemovedPersistentVolumeClaimFromVolumeGroup      = "Successfully removed %s/%s persistentVolumeClaim from %s/%s volumeGroup"
 	PersistentVolumeClaimDoesNotHavePersistentVolume = "PersistentVolumeClaim does not Have persistentVolume"
 	GetPersistentVolumeOfPersistentVolumeClaim       = "Get matching persistentVolume from %s/%s persistentVolumeClaim"
 	GetVolumeGroupContentOfVolumeGroup               = "Get matching volumeGroupContent from %s/%s VolumeGroup"
-	RemovePersistentVolumeFromVolumeGroupContent     = "Removing %s persistentVolume from %s/%s volumeGroupContent"
-	RemovedPersistentVolumeFromVolumeGroupContent    = "Successfully removed %s persistentVolume from %s/%s volumeGroupContent"
 	FailedToModifyVolumeGroup                        = "Failed to modify %s/%s volumeGroup"
-	AddPersistentVolumeClaimToVolumeGroup            = "Adding %s/%s persistentVolumeClaim to %s/%s volumeGroup"
-	AddedPersistentVolumeClaimToVolumeGroup          = "Successfully added %s/%s persistentVolumeClaim to %s/%s volumeGroup"
-	AddPersistentVolumeToVolumeGroupContent          = "Adding %s persistentVolume to %s/%s volumeGroup"
-	AddedPersistentVolumeToVolumeGroupContent        = "Successfully added %s persistentVolume to %s/%s volumeGroupContent"
-	RemoveVolumeFromVolumeGroup                      = "Removing volume of persistentVolumeClaim from %s/%s volumeGroup"
-	RemovedVolumeFromVolumeGroup                     = "Successfully Removed volume of persistentVolumeClaim from %s/%s volumeGroup"
 	ModifyVolumeGroup                                = "Modifying %s volumeGroupID with %v volumeIDs"
 	ModifiedVolumeGroup                              = "Successfully modified %s volumeGroupID"
 	CreateEventForNamespacedObject                   = "Creating event for %s/%s %s, with [%s] message"
@@ -48,14 +40,47 @@ var (
 	UpdateVolumeGroupStatus                          = "Updating status of %s/%s volumeGroup"
 	GetPersistentVolumeClaim                         = "Getting %s/%s persistentVolumeClaim"
 	GetPersistentVolume                              = "Getting %s persistentVolume"
-	AddVolumeToVolumeGroup                           = "Adding volume of persistentVolumeClaim to %s/%s volumeGroup"
-	AddedVolumeToVolumeGroup                         = "Successfully added volume of persistentVolumeClaim to %s/%s volumeGroup"
 	PersistentVolumeClaimIsNotInBoundPhase           = "PersistentVolumeClaim is not in bound phase, stopping the reconcile, when it will be in bound phase, reconcile will continue"
 	StorageClassHasVGParameter                       = "StorageClass %s contain parameter volume_group for claim %s/%s. volumegroup feature is not supported"
 	ListPersistentVolumeClaim                        = "Listing PersistentVolumeClaims"
 	VolumeGroupCreated                               = "Successfully Created  %s/%s volumeGroup"
-	VolumeGroupContentCreated                        = "Successfully Created  %s/%s volumeGroupContent"
+	VolumeGroupContentCreated                        = "Successfully Created  %s volumeGroupContent"
 	RetryUpdateVolumeGroupStatus                     = "Retry update %s/%s volumeGroup status due to conflict error"
-	RetryUpdateVolumeGroupContentStatus              = "Retry update %s/%s volumeGroupContent status due to conflict error"
+	RetryUpdateVolumeGroupContentStatus              = "Retry update %s volumeGroupContent status due to conflict error"
 	RetryUpdateFinalizer                             = "Retry update finalizer due to conflict error"
+	VolumeGroupBound                                 = "VolumeGroup is bound to %s volumeGroupContent"
+	VolumeGroupContentBound                          = "VolumeGroupContent is bound to %s/%s volumeGroup"
+	GetDefaultVolumeGroupClass                       = "Getting default volumeGroupClass of %s driver"
+	SetDefaultVolumeGroupClass                       = "Setting %s/%s volumeGroup class to default %s volumeGroupClass"
+	RetainVolumeGroupContent                         = "Retaining %s volumeGroupContent and its volume group on the storage"
+	VolumeGroupContentReleased                       = "VolumeGroupContent was released from %s/%s volumeGroup"
+	VolumeGroupContentAvailable                      = "VolumeGroupContent is not bound to any volumeGroup"
+	DeletePersistentVolumeClaim                      = "Deleting %s/%s persistentVolumeClaim of %s/%s volumeGroup"
+	PersistentVolumeClaimIsPartOfOtherVolumeGroups   = "%s/%s persistentVolumeClaim is part of other volumeGroups, it is not deleted with %s/%s volumeGroup"
+	WaitingForPersistentVolumeClaimsDeletion         = "Waiting for %d persistentVolumeClaims to be deleted"
+	GetVolumeGroup                                   = "Getting %s volumeGroupID from the storage"
+	VolumeGroupGetIsNotSupported                     = "The driver does not support ControllerGetVolumeGroup, skipping membership check"
+	VolumeGroupMembershipInSync                      = "Membership of %s/%s volumeGroup matches the storage"
+	VolumeGroupMembershipRepaired                    = "Membership of %s volumeGroupID on the storage was set back to the members of %s/%s volumeGroup"
+	UnableToCreateOrphanedVGCollector                = "Unable to create orphaned volume groups collector"
+	ListStorageVolumeGroups                          = "Listing volume groups on the storage"
+	VolumeGroupListIsNotSupported                    = "The driver does not support ListVolumeGroups, skipping orphaned volume groups check"
+	OrphanedVolumeGroupFound                         = "%s volumeGroupID on the storage belongs to %s volumeGroup UID that no longer exists"
+	DeleteOrphanedVolumeGroup                        = "Deleting orphaned %s volumeGroupID from the storage"
+	DeletedOrphanedVolumeGroup                       = "Successfully deleted orphaned %s volumeGroupID from the storage"
+	RetryUpdateVolumeGroupAnnotation                 = "Retry update %s annotation of %s/%s volumeGroup due to conflict error"
+	SaveVolumeGroupName                              = "Saving %s name of the group on the storage to %s/%s volumeGroup"
+	SaveVolumeGroupHandle                            = "Saving %s volumeGroupID to %s/%s volumeGroup"
+	ResumeVolumeGroupCreation                        = "Continuing creation of %s/%s volumeGroup with saved %s volumeGroupID"
+	ResumeVolumeGroupCreationByName                  = "Continuing creation of %s/%s volumeGroup with %s volumeGroupID found on the storage by its saved %s name"
+	VolumeGroupListIsNotSupportedOnResume            = "The driver does not support ListVolumeGroups, %s group is created again and the driver must return the existing group"
+	RetainVolumeGroupWithoutContent                  = "%s volumeGroupID has no volumeGroupContent and its volumeGroupClass retains it, it is kept on the storage"
+	TerminalDriverErrorIsNotRetried                  = "The driver rejected the request with a terminal error, it is not retried until the object changes"
+	DriverIsDisconnected                             = "The connection to the driver is down, requeueing"
+	ModifyVolumeGroupMembership                      = "Adding %d and removing %d persistentVolumeClaims of %s/%s volumeGroup"
+	AddedPersistentVolumeClaimsToVolumeGroup         = "Successfully added %d persistentVolumeClaims to %s/%s volumeGroup"
+	RemovedPersistentVolumeClaimsFromVolumeGroup     = "Successfully removed %d persistentVolumeClaims from %s/%s volumeGroup"
+	QueueAddPersistentVolumeClaimToVolumeGroup       = "Queueing %s/%s persistentVolumeClaim to be added to %s/%s volumeGroup"
+	QueueRemovePersistentVolumeClaimFromVolumeGroup  = "Queueing %s/%s persistentVolumeClaim to be removed from %s/%s volumeGroup"
+	DropQueuedPersistentVolumeClaim                  = "%s/%s persistentVolumeClaim can no longer be added to %s/%s volumeGroup, it is dropped from the batch"
 )
@@ -18,12 +18,11 @@ package messages
 
 var (
 	MatchingLabelsAndLabelSelectorFailed                 = "Could not check if labels are matched with labelSelector, got %s"
-	FailedToRemovePersistentVolumeClaimFromVolumeGroup   = "Could not remove %s/%s persistentVolumeClaim from %s/%s volumeGroup"
 	PersistentVolumeDoesNotExist                         = "%s/%s persistentVolume does not exist"
+	PersistentVolumeIsNotCSI                             = "%s persistentVolume is not a CSI volume, only CSI volumes can be added to a volumeGroup"
+	PersistentVolumeIsMigratedToCSI                      = "%s persistentVolume is an in-tree volume migrated to %s CSI driver, only CSI volumes can be added to a volumeGroup"
 	UnExpectedPersistentVolumeClaimError                 = "Got an unexpected error while fetching %s/%s PersistentVolumeClaim"
-	FailedToRemovePersistentVolumeFromVolumeGroupContent = "Could not remove %s persistentVolume from %s/%s volumeGroupContent"
-	FailedToAddPersistentVolumeClaimToVolumeGroup        = "Could not add %s/%s persistentVolumeClaim to %s/%s volumeGroup"
-	FailedToAddPersistentVolumeToVolumeGroupContent      = "Could not add %s persistentVolume to %s/%s volumeGroupContent"
+	FailedToRemovePersistentVolumeFromVolumeGroupContent = "Could not remove %s persistentVolume from %s volumeGroupContent"
 	FailedToCreateEvent                                  = "Failed to create %s/%s event"
 	FailedToGetPersistentVolumeClaim                     = "Failed to get %s/%s persistentVolumeClaim"
 	FailedToGetPersistentVolume                          = "Failed to get %s persistentVolume"
@@ -31,5 +30,32 @@ var (
 	PersistentVolumeClaimMatchedWithMultipleNewGroups    = "Failed to add %s/%s persistentVolumeClaim to VolumeGroups %v Because it matched more than one new VolumeGroups"
 	FailedToGetStorageClass                              = "Failed to get %s storageClass"
 	FailedToListPersistentVolumeClaim                    = "Failed to list persistentVolumeClaim"
+	FailedToDeletePersistentVolumeClaim                  = "Failed to delete %s/%s persistentVolumeClaim"
+	FailedToAddInitialVolumesToVolumeGroup               = "Failed to add the initial volumes to %s volumeGroupID"
+	FailedToRemoveVolumesFromDeletedVolumeGroup          = "Failed to remove the volumes from %s volumeGroupID before deleting their persistentVolumeClaims"
+	VolumeGroupMembershipDrift                           = "Membership of %s volumeGroupID differs from the storage, missing volumes %v, unexpected volumes %v"
+	FailedToGetVolumeGroup                               = "Failed to get %s volumeGroupID from the storage"
 	FailedToGetStorageClassName                          = "Failed to get storageClass name from persistentVolumeClaim %s"
+	VolumeGroupSourceHasBothOptions                      = "only one of volumeGroupContentName and selector can be set"
+	VolumeGroupSourceHasNoOption                         = "one of volumeGroupContentName, selector and persistentVolumeClaimNames must be set"
+	VolumeGroupSourceHasSelectorAndNames                 = "selector cannot be set with persistentVolumeClaimNames"
+	OnlyMembersCanChangeAfterBinding                     = "only persistentVolumeClaimNames can be changed after the group is bound"
+	VolumeGroupClassHasInvalidParameters                 = "volumeGroupClass has invalid parameters: %s"
+	FieldIsImmutableAfterBinding                         = "field is immutable after the group is bound"
+	FieldIsImmutable                                     = "field is immutable"
+	UnexpectedObjectType                                 = "expected a %s object but got %T"
+	FailedToListVolumeGroupClasses                       = "Failed to list volumeGroupClasses"
+	FailedToMapPersistentVolumeClaimToVolumeGroups       = "Failed to find the volumeGroups of the persistentVolumeClaim"
+	FailedToMapVolumeGroupClassToVolumeGroups            = "Failed to find the volumeGroups of the volumeGroupClass"
+	NoDefaultVolumeGroupClass                            = "No default volumeGroupClass found for %s driver"
+	MultipleDefaultVolumeGroupClasses                    = "Found multiple default volumeGroupClasses for %s driver: %v"
+	VolumeGroupContentBoundToOtherVolumeGroup            = "%s volumeGroupContent is already bound to %s/%s volumeGroup"
+	VolumeGroupContentReservedForOtherVolumeGroup        = "%s volumeGroupContent is reserved for %s/%s volumeGroup"
+	FailedToListStorageVolumeGroups                      = "Failed to list volume groups on the storage"
+	FailedToDeleteOrphanedVolumeGroup                    = "Failed to delete orphaned %s volumeGroupID from the storage"
+	FailedToListVolumeGroups                             = "Failed to list volumeGroups"
+	FailedToListVolumeGroupContents                      = "Failed to list volumeGroupContents"
+	DriverIsNotServed                                    = "%s driver is not served by this operator"
+	FailedToUpdatePersistentVolumeClaimsOfVolumeGroup    = "Could not update persistentVolumeClaims of %s/%s volumeGroup"
+	FailedToUpdatePersistentVolumesOfVolumeGroupContent  = "Could not update persistentVolumes of %s volumeGroupContent"
 )
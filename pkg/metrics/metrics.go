@@ -0,0 +1,107 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	vgerrors "github.com/IBM/csi-volume-group-operator/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	metricsPrefix = "csi_volume_group_"
+
+	ReconcileSuccess       = "success"
+	ReconcileRequeue       = "requeue"
+	ReconcileError         = "error"
+	ReconcileTerminalError = "terminal_error"
+
+	ConflictAlreadyInGroup    = "already_in_group"
+	ConflictMultipleNewGroups = "multiple_new_groups"
+)
+
+var (
+	driverRPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    metricsPrefix + "driver_rpc_duration_seconds",
+		Help:    "Latency of the requests to the CSI drivers by driver, method and gRPC code.",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"driver", "method", "grpc_code"})
+
+	driverRPCErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "driver_rpc_errors_total",
+		Help: "Number of failed requests to the CSI drivers by driver, method and gRPC code.",
+	}, []string{"driver", "method", "grpc_code"})
+
+	driverReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricsPrefix + "driver_ready",
+		Help: "Whether the last health check of the CSI driver succeeded.",
+	}, []string{"driver"})
+
+	membershipConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "membership_conflicts_total",
+		Help: "Number of persistentVolumeClaims that could not be added to a volumeGroup because of other volumeGroups.",
+	}, []string{"reason"})
+
+	reconcileOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "reconcile_total",
+		Help: "Number of reconciles by controller and outcome.",
+	}, []string{"controller", "outcome"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(driverRPCDuration, driverRPCErrors, driverReady, membershipConflicts, reconcileOutcomes)
+}
+
+// ObserveDriverRPC records the latency of a request to the driver, and the error when it failed.
+func ObserveDriverRPC(driver, method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	driverRPCDuration.WithLabelValues(driver, method, code).Observe(time.Since(start).Seconds())
+	if err != nil {
+		driverRPCErrors.WithLabelValues(driver, method, code).Inc()
+	}
+}
+
+func SetDriverReady(driver string, ready bool) {
+	value := 0.0
+	if ready {
+		value = 1
+	}
+	driverReady.WithLabelValues(driver).Set(value)
+}
+
+func IncMembershipConflicts(reason string) {
+	membershipConflicts.WithLabelValues(reason).Inc()
+}
+
+// RecordReconcileOutcome counts the result of a reconcile, it must be called with the error before
+// terminal driver errors are dropped.
+func RecordReconcileOutcome(controller string, result reconcile.Result, err error) {
+	outcome := ReconcileSuccess
+	switch {
+	case vgerrors.IsTerminalDriverError(err):
+		outcome = ReconcileTerminalError
+	case err != nil:
+		outcome = ReconcileError
+	case result.Requeue || result.RequeueAfter > 0:
+		outcome = ReconcileRequeue
+	}
+	reconcileOutcomes.WithLabelValues(controller, outcome).Inc()
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+
+	volumegroupv2 "github.com/IBM/csi-volume-group-operator/api/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	volumeGroupsDesc = prometheus.NewDesc(metricsPrefix+"volume_groups",
+		"Number of volumeGroups by volumeGroupClass and readiness.", []string{"volume_group_class", "ready"}, nil)
+	volumeGroupMembersDesc = prometheus.NewDesc(metricsPrefix+"volume_group_members",
+		"Number of persistentVolumeClaims in a volumeGroup.", []string{"namespace", "volume_group"}, nil)
+)
+
+// volumeGroupCollector reads the volumeGroups on every scrape, so deleted groups are not left behind
+// as stale series.
+type volumeGroupCollector struct {
+	listVolumeGroups func() ([]volumegroupv2.VolumeGroup, error)
+}
+
+// RegisterVolumeGroupCollector registers the metrics of the volumeGroups returned by listVolumeGroups,
+// it should read from the cache because it is called on every scrape.
+func RegisterVolumeGroupCollector(listVolumeGroups func() ([]volumegroupv2.VolumeGroup, error)) error {
+	return metrics.Registry.Register(&volumeGroupCollector{listVolumeGroups: listVolumeGroups})
+}
+
+func (c *volumeGroupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- volumeGroupsDesc
+	ch <- volumeGroupMembersDesc
+}
+
+func (c *volumeGroupCollector) Collect(ch chan<- prometheus.Metric) {
+	vgs, err := c.listVolumeGroups()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(volumeGroupsDesc, err)
+		return
+	}
+
+	type classReadiness struct {
+		class string
+		ready string
+	}
+	counts := map[classReadiness]int{}
+	for _, vg := range vgs {
+		class := ""
+		if vg.Spec.VolumeGroupClassName != nil {
+			class = *vg.Spec.VolumeGroupClassName
+		}
+		ready := strings.ToLower(getReadyStatus(vg))
+		counts[classReadiness{class: class, ready: ready}]++
+		ch <- prometheus.MustNewConstMetric(volumeGroupMembersDesc, prometheus.GaugeValue,
+			float64(len(vg.Status.Members)), vg.Namespace, vg.Name)
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(volumeGroupsDesc, prometheus.GaugeValue, float64(count), key.class, key.ready)
+	}
+}
+
+func getReadyStatus(vg volumegroupv2.VolumeGroup) string {
+	condition := meta.FindStatusCondition(vg.Status.Conditions, volumegroupv2.ConditionReady)
+	if condition == nil {
+		return "Unknown"
+	}
+	return string(condition.Status)
+}
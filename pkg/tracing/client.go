@@ -0,0 +1,123 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// tracedClient starts a span for every request of the Kubernetes client, reads that are served
+// from the cache get spans too so the time they take is visible in the reconcile.
+type tracedClient struct {
+	client.Client
+}
+
+// NewClient returns a client that traces the requests of c.
+func NewClient(c client.Client) client.Client {
+	return &tracedClient{Client: c}
+}
+
+func (c *tracedClient) startSpan(ctx context.Context, operation string, obj client.Object) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "k8s."+operation+" "+c.kindOf(obj), trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("k8s.namespace.name", obj.GetNamespace()),
+			attribute.String("k8s.object.name", obj.GetName()),
+		))
+}
+
+func (c *tracedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	ctx, span := tracer().Start(ctx, "k8s.Get "+c.kindOf(obj), trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("k8s.namespace.name", key.Namespace),
+			attribute.String("k8s.object.name", key.Name),
+		))
+	err := c.Client.Get(ctx, key, obj, opts...)
+	EndSpan(span, err)
+	return err
+}
+
+func (c *tracedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	ctx, span := tracer().Start(ctx, "k8s.List "+c.kindOf(list), trace.WithSpanKind(trace.SpanKindClient))
+	err := c.Client.List(ctx, list, opts...)
+	EndSpan(span, err)
+	return err
+}
+
+func (c *tracedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := c.startSpan(ctx, "Create", obj)
+	err := c.Client.Create(ctx, obj, opts...)
+	EndSpan(span, err)
+	return err
+}
+
+func (c *tracedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := c.startSpan(ctx, "Delete", obj)
+	err := c.Client.Delete(ctx, obj, opts...)
+	EndSpan(span, err)
+	return err
+}
+
+func (c *tracedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := c.startSpan(ctx, "Update", obj)
+	err := c.Client.Update(ctx, obj, opts...)
+	EndSpan(span, err)
+	return err
+}
+
+func (c *tracedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := c.startSpan(ctx, "Patch", obj)
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	EndSpan(span, err)
+	return err
+}
+
+func (c *tracedClient) Status() client.StatusWriter {
+	return &tracedStatusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+func (c *tracedClient) kindOf(obj runtime.Object) string {
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		return gvk.Kind
+	}
+	return fmt.Sprintf("%T", obj)
+}
+
+type tracedStatusWriter struct {
+	client.StatusWriter
+	client *tracedClient
+}
+
+func (w *tracedStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := w.client.startSpan(ctx, "UpdateStatus", obj)
+	err := w.StatusWriter.Update(ctx, obj, opts...)
+	EndSpan(span, err)
+	return err
+}
+
+func (w *tracedStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := w.client.startSpan(ctx, "PatchStatus", obj)
+	err := w.StatusWriter.Patch(ctx, obj, patch, opts...)
+	EndSpan(span, err)
+	return err
+}
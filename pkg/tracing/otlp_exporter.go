@@ -0,0 +1,241 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const httpExportTimeout = 10 * time.Second
+
+// otlpExporter encodes spans as an OTLP/JSON ExportTraceServiceRequest, the format of OTLP/HTTP
+// with JSON encoding and of the collector file exporter.
+type otlpExporter struct {
+	mu    sync.Mutex
+	write func(ctx context.Context, body []byte) error
+	close func() error
+}
+
+func newOTLPHTTPExporter(endpoint string) *otlpExporter {
+	httpClient := &http.Client{Timeout: httpExportTimeout}
+	return &otlpExporter{
+		write: func(ctx context.Context, body []byte) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode < 200 || resp.StatusCode > 299 {
+				return fmt.Errorf("failed to export spans to %s: %s", endpoint, resp.Status)
+			}
+			return nil
+		},
+		close: func() error { return nil },
+	}
+}
+
+func newOTLPFileExporter(path string) (*otlpExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &otlpExporter{
+		write: func(_ context.Context, body []byte) error {
+			_, err := file.Write(append(body, '\n'))
+			return err
+		},
+		close: file.Close,
+	}, nil
+}
+
+func (e *otlpExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(encodeSpans(spans))
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.write(ctx, body)
+}
+
+func (e *otlpExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.close()
+}
+
+type exportTraceRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   otlpResource `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            otlpStatus `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// encodeSpans groups the spans by resource and instrumentation scope, as OTLP expects.
+func encodeSpans(spans []sdktrace.ReadOnlySpan) exportTraceRequest {
+	request := exportTraceRequest{}
+	resourceIndex := map[string]int{}
+	scopeIndex := map[string]map[string]int{}
+	for _, span := range spans {
+		resourceKey := span.Resource().Encoded(attribute.DefaultEncoder())
+		i, ok := resourceIndex[resourceKey]
+		if !ok {
+			i = len(request.ResourceSpans)
+			resourceIndex[resourceKey] = i
+			scopeIndex[resourceKey] = map[string]int{}
+			request.ResourceSpans = append(request.ResourceSpans, resourceSpans{
+				Resource: otlpResource{Attributes: encodeAttributes(span.Resource().Attributes())},
+			})
+		}
+		scope := span.InstrumentationScope()
+		j, ok := scopeIndex[resourceKey][scope.Name]
+		if !ok {
+			j = len(request.ResourceSpans[i].ScopeSpans)
+			scopeIndex[resourceKey][scope.Name] = j
+			request.ResourceSpans[i].ScopeSpans = append(request.ResourceSpans[i].ScopeSpans, scopeSpans{
+				Scope: otlpScope{Name: scope.Name, Version: scope.Version},
+			})
+		}
+		request.ResourceSpans[i].ScopeSpans[j].Spans = append(request.ResourceSpans[i].ScopeSpans[j].Spans, encodeSpan(span))
+	}
+	return request
+}
+
+func encodeSpan(span sdktrace.ReadOnlySpan) otlpSpan {
+	encoded := otlpSpan{
+		TraceID:           span.SpanContext().TraceID().String(),
+		SpanID:            span.SpanContext().SpanID().String(),
+		Name:              span.Name(),
+		Kind:              encodeSpanKind(span.SpanKind()),
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime().UnixNano(), 10),
+		Attributes:        encodeAttributes(span.Attributes()),
+		Status:            encodeStatus(span.Status()),
+	}
+	if span.Parent().HasSpanID() {
+		encoded.ParentSpanID = span.Parent().SpanID().String()
+	}
+	return encoded
+}
+
+// encodeSpanKind maps the span kind to the OTLP enum, which has no value for trace.SpanKindUnspecified.
+func encodeSpanKind(kind trace.SpanKind) int {
+	if kind == trace.SpanKindUnspecified {
+		return int(trace.SpanKindInternal)
+	}
+	return int(kind)
+}
+
+// encodeStatus maps the status code to the OTLP enum, where Ok is 1 and Error is 2.
+func encodeStatus(status sdktrace.Status) otlpStatus {
+	switch status.Code {
+	case codes.Ok:
+		return otlpStatus{Code: 1}
+	case codes.Error:
+		return otlpStatus{Code: 2, Message: status.Description}
+	}
+	return otlpStatus{}
+}
+
+func encodeAttributes(attributes []attribute.KeyValue) []keyValue {
+	encoded := make([]keyValue, 0, len(attributes))
+	for _, kv := range attributes {
+		value := anyValue{}
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			b := kv.Value.AsBool()
+			value.BoolValue = &b
+		case attribute.INT64:
+			i := strconv.FormatInt(kv.Value.AsInt64(), 10)
+			value.IntValue = &i
+		case attribute.FLOAT64:
+			f := kv.Value.AsFloat64()
+			value.DoubleValue = &f
+		default:
+			s := kv.Value.Emit()
+			value.StringValue = &s
+		}
+		encoded = append(encoded, keyValue{Key: string(kv.Key), Value: value})
+	}
+	return encoded
+}
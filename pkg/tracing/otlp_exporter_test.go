@@ -0,0 +1,265 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	testTraceID      = trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	testSpanID       = trace.SpanID{0xa1, 0xa2, 0xa3, 0xa4, 0xa5, 0xa6, 0xa7, 0xa8}
+	testParentSpanID = trace.SpanID{0xb1, 0xb2, 0xb3, 0xb4, 0xb5, 0xb6, 0xb7, 0xb8}
+	testStartTime    = time.Unix(1664625600, 100)
+	testEndTime      = time.Unix(1664625601, 200)
+)
+
+func newTestSpan(name string, res *resource.Resource, scope string) tracetest.SpanStub {
+	return tracetest.SpanStub{
+		Name:                   name,
+		SpanContext:            trace.NewSpanContext(trace.SpanContextConfig{TraceID: testTraceID, SpanID: testSpanID}),
+		StartTime:              testStartTime,
+		EndTime:                testEndTime,
+		Resource:               res,
+		InstrumentationLibrary: instrumentation.Library{Name: scope, Version: "v1"},
+	}
+}
+
+// TestEncodeSpansGroupsByResourceAndScope checks that spans are grouped under their resource and
+// then under their instrumentation scope, in the order they are first seen.
+func TestEncodeSpansGroupsByResourceAndScope(t *testing.T) {
+	operatorResource := resource.NewSchemaless(attribute.String("service.name", "operator"))
+	driverResource := resource.NewSchemaless(attribute.String("service.name", "driver"))
+	request := encodeSpans(tracetest.SpanStubs{
+		newTestSpan("reconcile", operatorResource, "controller"),
+		newTestSpan("rpc", driverResource, "grpc"),
+		newTestSpan("list", operatorResource, "client"),
+		newTestSpan("update", operatorResource, "controller"),
+	}.Snapshots())
+
+	if len(request.ResourceSpans) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(request.ResourceSpans))
+	}
+	expectedResourceAttributes := []keyValue{{Key: "service.name", Value: anyValue{StringValue: stringPtr("operator")}}}
+	if !reflect.DeepEqual(request.ResourceSpans[0].Resource.Attributes, expectedResourceAttributes) {
+		t.Errorf("expected resource attributes %+v, got %+v", expectedResourceAttributes, request.ResourceSpans[0].Resource.Attributes)
+	}
+	expectedSpans := [][]string{{"reconcile", "update"}, {"list"}}
+	scopes := request.ResourceSpans[0].ScopeSpans
+	if len(scopes) != len(expectedSpans) {
+		t.Fatalf("expected %d scopes of the first resource, got %d", len(expectedSpans), len(scopes))
+	}
+	if scopes[0].Scope != (otlpScope{Name: "controller", Version: "v1"}) || scopes[1].Scope.Name != "client" {
+		t.Errorf("expected scopes controller and client, got %+v and %+v", scopes[0].Scope, scopes[1].Scope)
+	}
+	for i, names := range expectedSpans {
+		spanNames := []string{}
+		for _, span := range scopes[i].Spans {
+			spanNames = append(spanNames, span.Name)
+		}
+		if !reflect.DeepEqual(spanNames, names) {
+			t.Errorf("expected spans %v in scope %s, got %v", names, scopes[i].Scope.Name, spanNames)
+		}
+	}
+	driverScopes := request.ResourceSpans[1].ScopeSpans
+	if len(driverScopes) != 1 || len(driverScopes[0].Spans) != 1 || driverScopes[0].Spans[0].Name != "rpc" {
+		t.Errorf("expected the second resource to have only the rpc span, got %+v", driverScopes)
+	}
+}
+
+func TestEncodeSpan(t *testing.T) {
+	stub := newTestSpan("reconcile", resource.Empty(), "controller")
+	stub.Parent = trace.NewSpanContext(trace.SpanContextConfig{TraceID: testTraceID, SpanID: testParentSpanID})
+	stub.SpanKind = trace.SpanKindServer
+	encoded := encodeSpan(stub.Snapshot())
+
+	expected := otlpSpan{
+		TraceID:           "0102030405060708090a0b0c0d0e0f10",
+		SpanID:            "a1a2a3a4a5a6a7a8",
+		ParentSpanID:      "b1b2b3b4b5b6b7b8",
+		Name:              "reconcile",
+		Kind:              2,
+		StartTimeUnixNano: "1664625600000000100",
+		EndTimeUnixNano:   "1664625601000000200",
+		Attributes:        []keyValue{},
+	}
+	if !reflect.DeepEqual(encoded, expected) {
+		t.Errorf("expected span %+v, got %+v", expected, encoded)
+	}
+
+	root := encodeSpan(newTestSpan("root", resource.Empty(), "controller").Snapshot())
+	if root.ParentSpanID != "" {
+		t.Errorf("expected a root span to have no parent, got %s", root.ParentSpanID)
+	}
+}
+
+func TestEncodeSpanKind(t *testing.T) {
+	tests := map[trace.SpanKind]int{
+		trace.SpanKindUnspecified: 1,
+		trace.SpanKindInternal:    1,
+		trace.SpanKindServer:      2,
+		trace.SpanKindClient:      3,
+		trace.SpanKindProducer:    4,
+		trace.SpanKindConsumer:    5,
+	}
+	for kind, expected := range tests {
+		if encoded := encodeSpanKind(kind); encoded != expected {
+			t.Errorf("expected span kind %s to be encoded as %d, got %d", kind, expected, encoded)
+		}
+	}
+}
+
+func TestEncodeStatus(t *testing.T) {
+	tests := []struct {
+		status   sdktrace.Status
+		expected otlpStatus
+	}{
+		{status: sdktrace.Status{Code: codes.Unset}, expected: otlpStatus{}},
+		{status: sdktrace.Status{Code: codes.Ok}, expected: otlpStatus{Code: 1}},
+		{status: sdktrace.Status{Code: codes.Error, Description: "driver failed"}, expected: otlpStatus{Code: 2, Message: "driver failed"}},
+	}
+	for _, tt := range tests {
+		if encoded := encodeStatus(tt.status); encoded != tt.expected {
+			t.Errorf("expected status %+v to be encoded as %+v, got %+v", tt.status, tt.expected, encoded)
+		}
+	}
+}
+
+// TestEncodeAttributes checks the JSON of every attribute type, integers are strings in OTLP/JSON
+// and the types without an OTLP value of their own are sent as strings.
+func TestEncodeAttributes(t *testing.T) {
+	body, err := json.Marshal(encodeAttributes([]attribute.KeyValue{
+		attribute.Bool("ready", true),
+		attribute.Int64("members", 200),
+		attribute.Float64("ratio", 0.5),
+		attribute.String("driver", "driver.example.com"),
+		attribute.StringSlice("volumes", []string{"volume-0", "volume-1"}),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"key":"ready","value":{"boolValue":true}},` +
+		`{"key":"members","value":{"intValue":"200"}},` +
+		`{"key":"ratio","value":{"doubleValue":0.5}},` +
+		`{"key":"driver","value":{"stringValue":"driver.example.com"}},` +
+		`{"key":"volumes","value":{"stringValue":"[volume-0 volume-1]"}}]`
+	if string(body) != expected {
+		t.Errorf("expected attributes\n%s\ngot\n%s", expected, body)
+	}
+}
+
+func TestOTLPHTTPExporter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("expected content type application/json, got %s", contentType)
+		}
+		request := exportTraceRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode the exported spans: %v", err)
+		}
+		if len(request.ResourceSpans) != 1 {
+			t.Errorf("expected 1 resource, got %d", len(request.ResourceSpans))
+		}
+	}))
+	defer server.Close()
+
+	exporter := newOTLPHTTPExporter(server.URL)
+	if err := exporter.ExportSpans(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	spans := tracetest.SpanStubs{newTestSpan("reconcile", resource.Empty(), "controller")}.Snapshots()
+	if err := exporter.ExportSpans(context.Background(), spans); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, an empty export sends nothing, got %d", requests)
+	}
+}
+
+func TestOTLPHTTPExporterReturnsCollectorErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	exporter := newOTLPHTTPExporter(server.URL)
+	spans := tracetest.SpanStubs{newTestSpan("reconcile", resource.Empty(), "controller")}.Snapshots()
+	err := exporter.ExportSpans(context.Background(), spans)
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("expected the 503 of the collector to be returned, got %v", err)
+	}
+
+	server.Close()
+	if err = exporter.ExportSpans(context.Background(), spans); err == nil {
+		t.Error("expected an error when the collector cannot be reached")
+	}
+}
+
+func TestOTLPFileExporterWritesOneRequestPerLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.json")
+	exporter, err := newOTLPFileExporter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans := tracetest.SpanStubs{newTestSpan("reconcile", resource.Empty(), "controller")}.Snapshots()
+	for i := 0; i < 2; i++ {
+		if err = exporter.ExportSpans(context.Background(), spans); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = exporter.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		request := exportTraceRequest{}
+		if err = json.Unmarshal([]byte(line), &request); err != nil {
+			t.Errorf("failed to decode line %q: %v", line, err)
+		}
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	tracerName  = "github.com/IBM/csi-volume-group-operator"
+	serviceName = "csi-volume-group-operator"
+)
+
+// Setup installs the global tracer provider. Spans are sent as OTLP/HTTP JSON to endpoint and
+// written as OTLP JSON lines to file, tracing stays disabled when both are empty. The returned
+// function flushes the pending spans.
+func Setup(endpoint, file string) (func(context.Context) error, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	}
+	if endpoint != "" {
+		options = append(options, sdktrace.WithBatcher(newOTLPHTTPExporter(endpoint)))
+	}
+	if file != "" {
+		exporter, err := newOTLPFileExporter(file)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	if endpoint == "" && file == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tracerProvider.Shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// StartReconcileSpan starts the span of a reconcile of the controller.
+func StartReconcileSpan(ctx context.Context, controller string, req reconcile.Request) (context.Context, trace.Span) {
+	return tracer().Start(ctx, controller+".Reconcile", trace.WithAttributes(
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("k8s.object.name", req.Name),
+	))
+}
+
+// StartDriverSpan starts the client span of a request to the CSI driver, and adds its trace context
+// to the outgoing gRPC metadata so the driver can continue the trace.
+func StartDriverSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	ctx, span := tracer().Start(ctx, "csi.VolumeGroup/"+method, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.method", method),
+		))
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// EndSpan records the error of the traced operation and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// metadataCarrier adapts gRPC metadata to the propagation.TextMapCarrier interface.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}